@@ -0,0 +1,77 @@
+package acceptance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// IdempotencyTestCase returns a test case which applies the configuration, applies it a second
+// time checking no resource was replaced, and plans it once more after a refresh. As the testing
+// framework fails a step whose plan isn't empty once applied, any perpetual diff in the resources
+// of the configuration fails the test case
+func IdempotencyTestCase(t *testing.T, config string, checkDestroy resource.TestCheckFunc) resource.TestCase {
+	ids := map[string]string{}
+
+	return resource.TestCase{
+		PreCheck:     func() { TestAccPreCheck(t) },
+		Providers:    TestAccProviders,
+		CheckDestroy: checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  recordResourceIDs(ids),
+			},
+			{
+				// nothing changed, so the second apply must leave every resource as it is
+				Config: config,
+				Check:  checkResourceIDsUnchanged(ids),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	}
+}
+
+// managedResources returns the resources of the state which aren't data sources, by address
+func managedResources(s *terraform.State) map[string]*terraform.ResourceState {
+	resources := map[string]*terraform.ResourceState{}
+	for address, rs := range s.RootModule().Resources {
+		if !strings.HasPrefix(address, "data.") {
+			resources[address] = rs
+		}
+	}
+	return resources
+}
+
+// recordResourceIDs records the IDs of the resources of the state in ids
+func recordResourceIDs(ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for address, rs := range managedResources(s) {
+			ids[address] = rs.Primary.ID
+		}
+		return nil
+	}
+}
+
+// checkResourceIDsUnchanged checks the resources of the state are the ones recorded in ids
+func checkResourceIDsUnchanged(ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resources := managedResources(s)
+		for address, id := range ids {
+			rs, ok := resources[address]
+			if !ok {
+				return fmt.Errorf("%s is gone after applying the same configuration again", address)
+			}
+			if rs.Primary.ID != id {
+				return fmt.Errorf("%s was replaced when applying the same configuration again: %s became %s", address, id, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
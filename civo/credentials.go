@@ -0,0 +1,129 @@
+package civo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-homedir"
+)
+
+// tokenCommandTimeout is how long token_command can run before the provider gives up on it
+const tokenCommandTimeout = 30 * time.Second
+
+// credentialSource is one of the places the provider can read the API token from.
+// token returns an empty string when the source isn't configured, so the next one is tried,
+// and an error when it is configured but the token can't be read from it
+type credentialSource struct {
+	name  string
+	token func(d *schema.ResourceData) (string, error)
+}
+
+// credentialChain returns the token sources in the order they are tried, the first one that
+// returns a token wins:
+//
+//  1. the token argument of the provider (deprecated)
+//  2. the CIVO_TOKEN environment variable
+//  3. the credentials_file argument (or CIVO_CREDENTIAL_FILE)
+//  4. the token_command argument (or CIVO_TOKEN_COMMAND)
+//  5. the Civo CLI config file, ~/.civo.json
+func credentialChain() []credentialSource {
+	return []credentialSource{
+		{name: "token argument", token: tokenFromArgument},
+		{name: "CIVO_TOKEN environment variable", token: tokenFromEnvironment},
+		{name: "credentials file", token: tokenFromCredentialsFile},
+		{name: "token command", token: tokenFromCommand},
+		{name: "CLI config file", token: tokenFromCLIConfig},
+	}
+}
+
+// resolveToken walks the credential chain and returns the first token found and the name of its source
+func resolveToken(d *schema.ResourceData) (string, string, error) {
+	for _, source := range credentialChain() {
+		token, err := source.token(d)
+		if err != nil {
+			return "", "", fmt.Errorf("error reading the token from the %s: %w", source.name, err)
+		}
+		if token != "" {
+			return token, source.name, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("none of the token argument, CIVO_TOKEN, credentials_file, token_command or ~/.civo.json is set")
+}
+
+func tokenFromArgument(d *schema.ResourceData) (string, error) {
+	// the token argument defaults to CIVO_TOKEN, so the same value comes from the environment
+	token := d.Get("token").(string)
+	if token == os.Getenv("CIVO_TOKEN") {
+		return "", nil
+	}
+	return token, nil
+}
+
+func tokenFromEnvironment(_ *schema.ResourceData) (string, error) {
+	return os.Getenv("CIVO_TOKEN"), nil
+}
+
+func tokenFromCredentialsFile(d *schema.ResourceData) (string, error) {
+	credFile := d.Get("credentials_file").(string)
+	if credFile == "" {
+		return "", nil
+	}
+
+	path, err := homedir.Expand(credFile)
+	if err != nil {
+		return "", fmt.Errorf("error expanding %v: %w", credFile, err)
+	}
+	return readTokenFromFile(path)
+}
+
+func tokenFromCommand(d *schema.ResourceData) (string, error) {
+	command := d.Get("token_command").(string)
+	if command == "" {
+		return "", nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("token_command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token_command didn't print a token")
+	}
+	return token, nil
+}
+
+func tokenFromCLIConfig(_ *schema.ResourceData) (string, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", nil
+	}
+
+	path := filepath.Join(homeDir, ".civo.json")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "", nil
+	}
+	return readTokenFromFile(path)
+}
@@ -0,0 +1,143 @@
+package civo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/go-homedir"
+)
+
+// writeCredentialsFile writes a credentials file in the format of the Civo CLI
+func writeCredentialsFile(t *testing.T, path, token string) {
+	t.Helper()
+
+	content := fmt.Sprintf(`{"apikeys":{"tf_key":"%s"},"meta":{"current_apikey":"tf_key"}}`, token)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write the credentials file: %s", err)
+	}
+}
+
+// isolateCredentials clears every credential source of the environment
+func isolateCredentials(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("CIVO_TOKEN", "")
+	t.Setenv("CIVO_CREDENTIAL_FILE", "")
+	t.Setenv("CIVO_TOKEN_COMMAND", "")
+
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = false })
+
+	return home
+}
+
+func TestResolveToken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the token commands below need a POSIX shell")
+	}
+
+	tests := []struct {
+		name       string
+		env        string
+		raw        map[string]interface{}
+		cliConfig  bool
+		wantToken  string
+		wantSource string
+		wantErr    string
+	}{
+		{
+			name:       "argument wins over the environment",
+			env:        "env-token",
+			raw:        map[string]interface{}{"token": "arg-token"},
+			wantToken:  "arg-token",
+			wantSource: "token argument",
+		},
+		{
+			name:       "environment wins over the credentials file",
+			env:        "env-token",
+			raw:        map[string]interface{}{"credentials_file": "CREDENTIALS_FILE"},
+			wantToken:  "env-token",
+			wantSource: "CIVO_TOKEN environment variable",
+		},
+		{
+			name:       "credentials file wins over the token command",
+			raw:        map[string]interface{}{"credentials_file": "CREDENTIALS_FILE", "token_command": "echo command-token"},
+			wantToken:  "file-token",
+			wantSource: "credentials file",
+		},
+		{
+			name:       "token command wins over the CLI config",
+			raw:        map[string]interface{}{"token_command": "echo ' command-token '"},
+			cliConfig:  true,
+			wantToken:  "command-token",
+			wantSource: "token command",
+		},
+		{
+			name:       "CLI config is the last resort",
+			raw:        map[string]interface{}{},
+			cliConfig:  true,
+			wantToken:  "cli-token",
+			wantSource: "CLI config file",
+		},
+		{
+			name:    "failing token command",
+			raw:     map[string]interface{}{"token_command": "echo boom >&2; exit 3"},
+			wantErr: "boom",
+		},
+		{
+			name:    "token command printing nothing",
+			raw:     map[string]interface{}{"token_command": "true"},
+			wantErr: "didn't print a token",
+		},
+		{
+			name:    "no source configured",
+			raw:     map[string]interface{}{},
+			wantErr: "none of the token argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := isolateCredentials(t)
+			t.Setenv("CIVO_TOKEN", tt.env)
+
+			credentialsFile := filepath.Join(t.TempDir(), "civo.json")
+			writeCredentialsFile(t, credentialsFile, "file-token")
+			if tt.cliConfig {
+				writeCredentialsFile(t, filepath.Join(home, ".civo.json"), "cli-token")
+			}
+
+			raw := map[string]interface{}{}
+			for k, v := range tt.raw {
+				if v == "CREDENTIALS_FILE" {
+					v = credentialsFile
+				}
+				raw[k] = v
+			}
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+			token, source, err := resolveToken(d)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if token != tt.wantToken || source != tt.wantSource {
+				t.Errorf("got token %q from %q, want %q from %q", token, source, tt.wantToken, tt.wantSource)
+			}
+		})
+	}
+}
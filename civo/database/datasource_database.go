@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -143,6 +144,7 @@ func dataSourceDatabaseRead(_ context.Context, d *schema.ResourceData, m interfa
 	d.Set("network_id", foundDatabase.NetworkID)
 	d.Set("firewall_id", foundDatabase.FirewallID)
 	d.Set("username", foundDatabase.Username)
+	redact.Register(foundDatabase.Password)
 	d.Set("password", foundDatabase.Password)
 	d.Set("endpoint", foundDatabase.PublicIPv4)
 	d.Set("dns_endpoint", fmt.Sprintf("%s.db.civo.com", foundDatabase.ID))
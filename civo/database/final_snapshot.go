@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// finalSnapshotName returns the name of the backup taken before the database is deleted. It ends
+// with the time it's taken, so a database recreated with the same name doesn't reuse the name of
+// the backup of the previous one
+func finalSnapshotName(databaseName string, now time.Time) string {
+	return fmt.Sprintf("%s-final-snapshot-%s", databaseName, now.UTC().Format("20060102150405"))
+}
+
+// takeFinalSnapshot takes a manual backup of the database and waits for it to be Ready, so the
+// database is only deleted once the backup is
+func takeFinalSnapshot(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client) error {
+	name := finalSnapshotName(d.Get("name").(string), time.Now())
+
+	log.Printf("[INFO] taking the final snapshot %s of the database %s", name, d.Id())
+	backup, err := apiClient.CreateDatabaseBackup(d.Id(), &civogo.DatabaseBackupCreateRequest{
+		Name:   name,
+		Type:   "manual",
+		Region: apiClient.Region,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to take the final snapshot %s: %s", name, err)
+	}
+
+	backupStateConf := &resource.StateChangeConf{
+		Pending: []string{"Pending"},
+		Target:  []string{"Ready"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := apiClient.GetDatabaseBackup(d.Id(), backup.ID)
+			if err != nil {
+				return 0, "", err
+			}
+			return resp, finalSnapshotState(resp.Status), nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := backupStateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the final snapshot %s to be finished: %s", name, err)
+	}
+
+	log.Printf("[INFO] final snapshot %s of the database %s taken", name, d.Id())
+	return nil
+}
+
+// finalSnapshotState maps the status of a backup to Ready when it's finished and Pending otherwise.
+// civogo doesn't list the statuses of a backup, so only Ready, the one a database reaches, is
+// trusted: a backup that never gets there makes the delete fail at its timeout, keeping the database
+func finalSnapshotState(status string) string {
+	if status == "Ready" {
+		return "Ready"
+	}
+	return "Pending"
+}
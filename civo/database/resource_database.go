@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
+	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -68,6 +71,19 @@ func ResourceDatabase() *schema.Resource {
 				Optional:    true,
 				Description: "The region where the database will be created.",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the database can't be deleted, nor replaced, until it's set back to false with an apply (default: false)",
+			},
+			"skip_final_snapshot": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "If set to false, a manual backup named `<name>-final-snapshot-<timestamp>` is taken and has to be `Ready` " +
+					"before the database is deleted. Otherwise the database is deleted without a final backup (default: true)",
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -109,16 +125,36 @@ func ResourceDatabase() *schema.Resource {
 		UpdateContext: resourceDatabaseUpdate,
 		DeleteContext: resourceDatabaseDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDatabaseImport,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
+		CustomizeDiff: customizeDiffDatabase,
 	}
 }
 
+// customizeDiffDatabase logs what's lost when a change replaces the database, and stops the plan
+// when the database is protected from deletion
+func customizeDiffDatabase(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if changed := utils.ReplacingChanges(d, "name", "network_id"); len(changed) > 0 {
+		oldName, _ := d.GetChange("name")
+		if protected, _ := d.GetChange("deletion_protection"); protected.(bool) {
+			return fmt.Errorf("the database %s can't be replaced to change %s while deletion_protection is set, set it to false with an apply first",
+				oldName, strings.Join(changed, ", "))
+		}
+
+		utils.LogReplacement(fmt.Sprintf("The database %s", oldName), changed, []string{
+			"all its data is deleted with it, take a backup first if it must be kept",
+			fmt.Sprintf("its %d nodes are replaced, so its endpoint, IP addresses and credentials change", d.Get("nodes").(int)),
+		})
+	}
+
+	return nil
+}
+
 // function to create a database
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
@@ -270,6 +306,7 @@ func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interfa
 	d.Set("firewall_id", resp.FirewallID)
 	d.Set("region", apiClient.Region)
 	d.Set("username", resp.Username)
+	redact.Register(resp.Password)
 	d.Set("password", resp.Password)
 	d.Set("endpoint", resp.PublicIPv4)
 	d.Set("dns_endpoint", fmt.Sprintf("%s.db.civo.com", resp.ID))
@@ -280,6 +317,14 @@ func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// resourceDatabaseImport imports the database with the default values of the arguments only used by the provider
+func resourceDatabaseImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("deletion_protection", false)
+	d.Set("skip_final_snapshot", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 // Function to delete the database
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
@@ -289,6 +334,16 @@ func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m inter
 		apiClient.Region = region.(string)
 	}
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("[ERR] the Database %s can't be deleted while deletion_protection is set, set it to false with an apply first", d.Id())
+	}
+
+	if !d.Get("skip_final_snapshot").(bool) {
+		if err := takeFinalSnapshot(ctx, d, apiClient); err != nil {
+			return diag.Errorf("[ERR] the Database %s isn't deleted: %s. Set skip_final_snapshot to true to delete it without one", d.Id(), err)
+		}
+	}
+
 	log.Printf("[INFO] deleting the Database %s", d.Id())
 	_, err := apiClient.DeleteDatabase(d.Id())
 	if err != nil {
@@ -2,6 +2,7 @@ package database_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/civo/civogo"
@@ -82,6 +83,43 @@ func TestAccCivoDatabase_update(t *testing.T) {
 	})
 }
 
+// CivoDatabase_deletionProtection is used to test the database can't be deleted while it's protected
+func TestAccCivoDatabase_deletionProtection(t *testing.T) {
+	var database civogo.Database
+
+	resName := "civo_database.foobar"
+	var databaseName = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoDatabaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoDatabaseConfigDeletionProtection(databaseName, true),
+				Check: resource.ComposeTestCheckFunc(
+					CivoDatabaseResourceExists(resName, &database),
+					resource.TestCheckResourceAttr(resName, "deletion_protection", "true"),
+					resource.TestCheckResourceAttr(resName, "skip_final_snapshot", "true"),
+				),
+			},
+			{
+				Config:      CivoDatabaseConfigDeletionProtection(databaseName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection is set"),
+			},
+			{
+				// the final destroy then takes the final snapshot before deleting the database
+				Config: CivoDatabaseConfigDeletionProtection(databaseName, false),
+				Check: resource.ComposeTestCheckFunc(
+					CivoDatabaseResourceExists(resName, &database),
+					resource.TestCheckResourceAttr(resName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 // CivoDatabaseConfig is used to configure the database resource
 func CivoDatabaseValues(database *civogo.Database, name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -166,3 +204,16 @@ resource "civo_database" "foobar" {
 	nodes = 2
 }`, name)
 }
+
+// CivoDatabaseConfigDeletionProtection is used to configure a database protected from deletion
+func CivoDatabaseConfigDeletionProtection(name string, protected bool) string {
+	return fmt.Sprintf(`
+resource "civo_database" "foobar" {
+	name = "%s"
+	size = "g3.db.xsmall"
+	engine = "Postgres"
+	version = "13"
+	nodes = 2
+	deletion_protection = %t
+}`, name, protected)
+}
@@ -0,0 +1,171 @@
+package disk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// diskImageRecord is a disk image with the flag telling if it's the newest of its distribution
+type diskImageRecord struct {
+	civogo.DiskImage
+	MostRecent bool
+}
+
+// DataSourceDiskImages Data source to list all the disk images of a region,
+// sorted by distribution and newest version first
+func DataSourceDiskImages() *schema.Resource {
+
+	dataListConfig := &datalist.ResourceConfig{
+		RecordSchema: diskImagesSchema(),
+		Description: "Get the disk images available in a region, with the ability to filter them by distribution and version. " +
+			"Images are sorted by distribution and then by version, newest first, and `most_recent` marks the newest image of each distribution.",
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If is used, all disk image will be from this region. Required if no region is set in provider.",
+			},
+		},
+		ResultAttributeName: "disk_images",
+		FlattenRecord:       flattenDiskImageRecord,
+		GetRecords:          getDiskImageRecords,
+	}
+
+	return datalist.NewResource(dataListConfig)
+}
+
+func getDiskImageRecords(m interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	region, ok := extra["region"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to find `region` key from query data")
+	}
+
+	if region != "" {
+		apiClient.Region = region
+	}
+
+	diskImages, err := apiClient.ListDiskImages()
+	if err != nil {
+		return nil, fmt.Errorf("[ERR] error retrieving all Disk Images: %s", err)
+	}
+
+	sortDiskImages(diskImages)
+
+	records := []interface{}{}
+	for i, v := range diskImages {
+		// the list is sorted, so the first image of each distribution is its newest one
+		mostRecent := i == 0 || diskImages[i-1].Distribution != v.Distribution
+		records = append(records, diskImageRecord{DiskImage: v, MostRecent: mostRecent})
+	}
+
+	return records, nil
+}
+
+// sortDiskImages sorts the images by distribution and then by version, newest first
+func sortDiskImages(diskImages []civogo.DiskImage) {
+	sort.SliceStable(diskImages, func(i, j int) bool {
+		if diskImages[i].Distribution != diskImages[j].Distribution {
+			return diskImages[i].Distribution < diskImages[j].Distribution
+		}
+		return compareVersions(diskImages[i].Version, diskImages[j].Version) > 0
+	})
+}
+
+// compareVersions compares two dotted versions segment by segment, numerically when both
+// segments are numbers (so 22.04 > 9.10) and as strings otherwise. It returns -1, 0 or 1
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		if i >= len(as) {
+			return -1
+		}
+		if i >= len(bs) {
+			return 1
+		}
+
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return 0
+}
+
+func flattenDiskImageRecord(record, _ interface{}, _ map[string]interface{}) (map[string]interface{}, error) {
+
+	s := record.(diskImageRecord)
+
+	flattened := map[string]interface{}{}
+	flattened["id"] = s.ID
+	flattened["name"] = s.Name
+	flattened["version"] = s.Version
+	flattened["label"] = s.Label
+	flattened["distribution"] = s.Distribution
+	flattened["description"] = s.Description
+	flattened["state"] = s.State
+	flattened["most_recent"] = s.MostRecent
+
+	return flattened, nil
+}
+
+func diskImagesSchema() map[string]*schema.Schema {
+
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of disk image",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of disk image",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Version of disk image",
+		},
+		"label": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Label of disk image",
+		},
+		"distribution": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Distribution of disk image, e.g. ubuntu or debian",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of disk image",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "State of disk image",
+		},
+		"most_recent": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "If this is the newest version of its distribution",
+		},
+	}
+}
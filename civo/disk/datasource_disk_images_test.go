@@ -0,0 +1,46 @@
+package disk_test
+
+import (
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoDiskImages_mostRecent(t *testing.T) {
+	datasourceName := "data.civo_disk_images.ubuntu"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoDiskImagesConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "disk_images.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "disk_images.0.distribution", "ubuntu"),
+					resource.TestCheckResourceAttr(datasourceName, "disk_images.0.most_recent", "true"),
+					resource.TestCheckResourceAttrSet(datasourceName, "disk_images.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoDiskImagesConfig() string {
+	return `
+data "civo_disk_images" "ubuntu" {
+	region = "LON1"
+
+	filter {
+		key    = "distribution"
+		values = ["ubuntu"]
+	}
+
+	filter {
+		key    = "most_recent"
+		values = ["true"]
+	}
+}
+`
+}
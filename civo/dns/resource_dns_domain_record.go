@@ -2,7 +2,9 @@ package dns
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"strings"
 
 	"github.com/civo/civogo"
@@ -12,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// dnsRecordTypeAAAA represents an AAAA record, civogo doesn't define it yet
+const dnsRecordTypeAAAA = "AAAA"
+
 // ResourceDNSDomainRecord DNS domain record resource with this we can create and manage DNS Domain
 func ResourceDNSDomainRecord() *schema.Resource {
 	return &schema.Resource{
@@ -25,9 +30,10 @@ func ResourceDNSDomainRecord() *schema.Resource {
 			"type": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The choice of RR type from a, cname, mx, ns or txt",
+				Description: "The choice of RR type from a, aaaa, cname, mx, ns or txt",
 				ValidateFunc: validation.StringInSlice([]string{
 					civogo.DNSRecordTypeA,
+					dnsRecordTypeAAAA,
 					civogo.DNSRecordTypeCName,
 					civogo.DNSRecordTypeMX,
 					civogo.DNSRecordTypeTXT,
@@ -38,12 +44,12 @@ func ResourceDNSDomainRecord() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The portion before the domain name (e.g. www) or an @ for the apex/root domain (you cannot use an A record with an amex/root domain)",
+				Description: "The portion before the domain name (e.g. www) or an @ for the apex/root domain (you cannot use an A record with an amex/root domain). NS records can only be created for subdomains, to delegate them to other name servers",
 			},
 			"value": {
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "The IP address (A or MX), hostname (CNAME or MX) or text value (TXT) to serve for this record",
+				Description:  "The IP address (A, AAAA or MX), hostname (CNAME, MX or NS) or text value (TXT) to serve for this record",
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"priority": {
@@ -83,6 +89,7 @@ func ResourceDNSDomainRecord() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSDomainRecordImport,
 		},
+		CustomizeDiff: customizeDiffDNSDomainRecord,
 	}
 }
 
@@ -108,6 +115,10 @@ func resourceDNSDomainRecordCreate(ctx context.Context, d *schema.ResourceData,
 		config.Type = civogo.DNSRecordTypeA
 	}
 
+	if d.Get("type").(string) == "AAAA" {
+		config.Type = dnsRecordTypeAAAA
+	}
+
 	if d.Get("type").(string) == "CNAME" {
 		config.Type = civogo.DNSRecordTypeCName
 	}
@@ -189,6 +200,10 @@ func resourceDNSDomainRecordUpdate(ctx context.Context, d *schema.ResourceData,
 			config.Type = civogo.DNSRecordTypeA
 		}
 
+		if d.Get("type").(string) == "AAAA" {
+			config.Type = dnsRecordTypeAAAA
+		}
+
 		if d.Get("type").(string) == "CNAME" {
 			config.Type = civogo.DNSRecordTypeCName
 		}
@@ -269,3 +284,35 @@ func resourceDNSDomainRecordImport(d *schema.ResourceData, m interface{}) ([]*sc
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// customizeDiffDNSDomainRecord checks that AAAA records point to an IPv6 address, and that NS records
+// delegate a subdomain, not the apex, to the hostname of a name server
+func customizeDiffDNSDomainRecord(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// the value may come from another resource, like an instance's ipv6_address, and be unknown until apply
+	if !d.NewValueKnown("value") || !d.NewValueKnown("type") {
+		return nil
+	}
+
+	value := d.Get("value").(string)
+
+	switch d.Get("type").(string) {
+	case dnsRecordTypeAAAA:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("the value %q is not a valid IPv6 address for an AAAA record", value)
+		}
+	case civogo.DNSRecordTypeNS:
+		// the apex NS records are the ones of Civo, overriding them would break the whole domain,
+		// NS records are meant to delegate a subdomain to other name servers
+		if !d.NewValueKnown("name") {
+			return nil
+		}
+		if name := d.Get("name").(string); name == "" || name == "@" {
+			return fmt.Errorf("NS records can't be created for the apex/root domain (name = %q), they are managed by Civo. Use the name of the subdomain to delegate, e.g. \"team\"", name)
+		}
+		if _, errs := utils.ValidateFQDN(value, "value"); len(errs) > 0 {
+			return fmt.Errorf("the value %q is not a valid name server for an NS record, it must be the hostname of the name server, e.g. ns1.example.net", value)
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package dns_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/civo/civogo"
@@ -77,6 +78,39 @@ func TestAccCivoDNSDomainNameRecord_update(t *testing.T) {
 	})
 }
 
+func TestAccCivoDNSDomainNameRecord_nsDelegation(t *testing.T) {
+	var domainRecord civogo.DNSRecord
+
+	// generate a random name for each test run
+	resName := "civo_dns_domain_record.ns"
+	var domainName = acctest.RandomWithPrefix("tf-test-record") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoDNSDomainNameRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoDNSDomainNameRecordConfigNS(domainName, "@", "ns1.example.net"),
+				ExpectError: regexp.MustCompile("can't be created for the apex/root domain"),
+			},
+			{
+				Config:      CivoDNSDomainNameRecordConfigNS(domainName, "team", "10.10.10.1"),
+				ExpectError: regexp.MustCompile("not a valid name server"),
+			},
+			{
+				Config: CivoDNSDomainNameRecordConfigNS(domainName, "team", "ns1.example.net"),
+				Check: resource.ComposeTestCheckFunc(
+					CivoDNSDomainNameRecordResourceExists(resName, &domainRecord),
+					resource.TestCheckResourceAttr(resName, "type", "NS"),
+					resource.TestCheckResourceAttr(resName, "name", "team"),
+					resource.TestCheckResourceAttr(resName, "value", "ns1.example.net"),
+				),
+			},
+		},
+	})
+}
+
 func CivoDNSDomainNameRecordValues(domainRecord *civogo.DNSRecord, name string) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		if domainRecord.Name != name {
@@ -166,3 +200,19 @@ resource "civo_dns_domain_record" "www" {
 }
 `, domain, record)
 }
+
+func CivoDNSDomainNameRecordConfigNS(domain, record, nameServer string) string {
+	return fmt.Sprintf(`
+resource "civo_dns_domain_name" "foobar" {
+	name = "%s"
+}
+
+resource "civo_dns_domain_record" "ns" {
+    domain_id = civo_dns_domain_name.foobar.id
+    type = "NS"
+    name = "%s"
+    value = "%s"
+    ttl = 3600
+}
+`, domain, record, nameServer)
+}
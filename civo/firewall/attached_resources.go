@@ -0,0 +1,187 @@
+package firewall
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withFirewallUsage adds the computed attributes set by setFirewallUsage to the given schema
+func withFirewallUsage(s map[string]*schema.Schema) map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"instance_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the instances using the firewall, Kubernetes nodes included",
+		},
+		"cluster_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the Kubernetes clusters using the firewall",
+		},
+		"loadbalancer_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs of the load balancers using the firewall",
+		},
+	}
+
+	for k, v := range attributes {
+		s[k] = v
+	}
+	return s
+}
+
+// firewallUsage is what uses a firewall, the IDs of each type of resource are sorted
+type firewallUsage struct {
+	instanceIDs     []string
+	clusterIDs      []string
+	loadBalancerIDs []string
+}
+
+// getFirewallUsage returns what uses the firewall among the resources of the current region. The
+// counts of the firewall tell which types of resources use it, so only those are listed
+func getFirewallUsage(apiClient *civogo.Client, firewall *civogo.Firewall) (*firewallUsage, error) {
+	usage := &firewallUsage{
+		instanceIDs:     []string{},
+		clusterIDs:      []string{},
+		loadBalancerIDs: []string{},
+	}
+
+	if firewall.InstanceCount > 0 {
+		instances, err := apiClient.ListAllInstances()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances: %s", err)
+		}
+		for _, instance := range instances {
+			if instance.FirewallID == firewall.ID {
+				usage.instanceIDs = append(usage.instanceIDs, instance.ID)
+			}
+		}
+	}
+
+	if firewall.ClusterCount > 0 {
+		clusters, err := apiClient.ListKubernetesClusters()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Kubernetes clusters: %s", err)
+		}
+		for _, cluster := range clusters.Items {
+			if cluster.FirewallID == firewall.ID {
+				usage.clusterIDs = append(usage.clusterIDs, cluster.ID)
+			}
+		}
+	}
+
+	if firewall.LoadBalancerCount > 0 {
+		loadBalancers, err := apiClient.ListLoadBalancers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list load balancers: %s", err)
+		}
+		for _, loadBalancer := range loadBalancers {
+			if loadBalancer.FirewallID == firewall.ID {
+				usage.loadBalancerIDs = append(usage.loadBalancerIDs, loadBalancer.ID)
+			}
+		}
+	}
+
+	sort.Strings(usage.instanceIDs)
+	sort.Strings(usage.clusterIDs)
+	sort.Strings(usage.loadBalancerIDs)
+
+	return usage, nil
+}
+
+// setFirewallUsage sets the IDs of the resources using the firewall
+func setFirewallUsage(d *schema.ResourceData, apiClient *civogo.Client, firewall *civogo.Firewall) error {
+	usage, err := getFirewallUsage(apiClient, firewall)
+	if err != nil {
+		return fmt.Errorf("failed to list the resources using the firewall %s: %s", firewall.ID, err)
+	}
+
+	d.Set("instance_ids", usage.instanceIDs)
+	d.Set("cluster_ids", usage.clusterIDs)
+	d.Set("loadbalancer_ids", usage.loadBalancerIDs)
+
+	return nil
+}
+
+// empty reports whether nothing uses the firewall
+func (u *firewallUsage) empty() bool {
+	return len(u.instanceIDs) == 0 && len(u.clusterIDs) == 0 && len(u.loadBalancerIDs) == 0
+}
+
+// String lists what uses the firewall, e.g. "instances a, b; Kubernetes clusters c"
+func (u *firewallUsage) String() string {
+	parts := []string{}
+	for _, group := range []struct {
+		name string
+		ids  []string
+	}{
+		{"instances", u.instanceIDs},
+		{"Kubernetes clusters", u.clusterIDs},
+		{"load balancers", u.loadBalancerIDs},
+	} {
+		if len(group.ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s", group.name, strings.Join(group.ids, ", ")))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// findNetworkDefaultFirewall returns the default firewall of the network of the firewall, the one
+// civo_network creates
+func findNetworkDefaultFirewall(apiClient *civogo.Client, firewall *civogo.Firewall) (*civogo.Firewall, error) {
+	network, err := apiClient.GetNetwork(firewall.NetworkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the network %s of the firewall: %s", firewall.NetworkID, err)
+	}
+
+	defaultFirewall, err := utils.FindDefaultFirewall(apiClient, network)
+	if err != nil {
+		return nil, err
+	}
+	if defaultFirewall == nil {
+		return nil, fmt.Errorf("the network %s has no default firewall named %s to move the resources to", network.Label, utils.DefaultFirewallName(network.Label))
+	}
+	if defaultFirewall.ID == firewall.ID {
+		return nil, fmt.Errorf("the firewall is the default firewall of the network %s, there is no other firewall to move its resources to", network.Label)
+	}
+
+	return defaultFirewall, nil
+}
+
+// reassignFirewallUsage moves what uses the firewall to the other firewall
+func reassignFirewallUsage(apiClient *civogo.Client, usage *firewallUsage, firewallID string) error {
+	for _, id := range usage.instanceIDs {
+		log.Printf("[INFO] moving the instance %s to the firewall %s", id, firewallID)
+		if _, err := apiClient.SetInstanceFirewall(id, firewallID); err != nil {
+			return fmt.Errorf("failed to move the instance %s to the firewall %s: %s", id, firewallID, err)
+		}
+	}
+
+	for _, id := range usage.clusterIDs {
+		log.Printf("[INFO] moving the Kubernetes cluster %s to the firewall %s", id, firewallID)
+		config := &civogo.KubernetesClusterConfig{FirewallID: firewallID, Region: apiClient.Region}
+		if _, err := apiClient.UpdateKubernetesCluster(id, config); err != nil {
+			return fmt.Errorf("failed to move the Kubernetes cluster %s to the firewall %s: %s", id, firewallID, err)
+		}
+	}
+
+	for _, id := range usage.loadBalancerIDs {
+		log.Printf("[INFO] moving the load balancer %s to the firewall %s", id, firewallID)
+		config := &civogo.LoadBalancerUpdateConfig{FirewallID: firewallID, Region: apiClient.Region}
+		if _, err := apiClient.UpdateLoadBalancer(id, config); err != nil {
+			return fmt.Errorf("failed to move the load balancer %s to the firewall %s: %s", id, firewallID, err)
+		}
+	}
+
+	return nil
+}
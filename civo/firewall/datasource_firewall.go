@@ -19,14 +19,16 @@ func DataSourceFirewall() *schema.Resource {
 			"Retrieve information about a firewall for use in other resources.",
 			"This data source provides all of the firewall's properties as configured on your Civo account.",
 			"Firewalls may be looked up by id or name, and you can optionally pass region if you want to make a lookup for a specific firewall inside that region.",
+			"The id or name must match exactly, and a name used by several firewalls of the region is an error, so the data source never picks a firewall by accident.",
 		}, "\n\n"),
 		ReadContext: dataSourceFirewallRead,
-		Schema: map[string]*schema.Schema{
+		Schema: withFirewallUsage(map[string]*schema.Schema{
 			"id": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.NoZeroValues,
 				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the firewall",
 			},
 			"name": {
 				Type:         schema.TypeString,
@@ -47,7 +49,67 @@ func DataSourceFirewall() *schema.Resource {
 				Computed:    true,
 				Description: "The id of the associated network",
 			},
-		},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The rules of the firewall",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the rule",
+						},
+						"direction": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The direction of the rule, `ingress` or `egress`",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the rule",
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The protocol of the rule, `tcp`, `udp` or `icmp`",
+						},
+						"port_range": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The port or port range of the rule, empty for icmp",
+						},
+						"cidr": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The CIDRs the rule applies to",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The action of the rule, `allow` or `deny`",
+						},
+					},
+				},
+			},
+			"instance_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of instances using the firewall",
+			},
+			"cluster_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of Kubernetes clusters using the firewall",
+			},
+			"loadbalancer_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of load balancers using the firewall",
+			},
+		}),
 	}
 }
 
@@ -63,7 +125,7 @@ func dataSourceFirewallRead(_ context.Context, d *schema.ResourceData, m interfa
 
 	if id, ok := d.GetOk("id"); ok {
 		log.Printf("[INFO] Getting the firewall by id")
-		firewall, err := apiClient.FindFirewall(id.(string))
+		firewall, err := findFirewallByIDOrName(apiClient, id.(string))
 		if err != nil {
 			return diag.Errorf("[ERR] failed to retrive firewall: %s", err)
 		}
@@ -71,7 +133,7 @@ func dataSourceFirewallRead(_ context.Context, d *schema.ResourceData, m interfa
 		foundFirewall = firewall
 	} else if name, ok := d.GetOk("name"); ok {
 		log.Printf("[INFO] Getting the firewall by name")
-		firewall, err := apiClient.FindFirewall(name.(string))
+		firewall, err := findFirewallByIDOrName(apiClient, name.(string))
 		if err != nil {
 			return diag.Errorf("[ERR] failed to retrive firewall: %s", err)
 		}
@@ -79,10 +141,44 @@ func dataSourceFirewallRead(_ context.Context, d *schema.ResourceData, m interfa
 		foundFirewall = firewall
 	}
 
+	// the list of firewalls doesn't always carry the rules, so they are fetched on their own
+	rules, err := apiClient.ListFirewallRules(foundFirewall.ID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to list the rules of the firewall %s: %s", foundFirewall.ID, err)
+	}
+
 	d.SetId(foundFirewall.ID)
 	d.Set("name", foundFirewall.Name)
 	d.Set("network_id", foundFirewall.NetworkID)
 	d.Set("region", apiClient.Region)
+	d.Set("instance_count", foundFirewall.InstanceCount)
+	d.Set("cluster_count", foundFirewall.ClusterCount)
+	d.Set("loadbalancer_count", foundFirewall.LoadBalancerCount)
+
+	if err := setFirewallUsage(d, apiClient, foundFirewall); err != nil {
+		return diag.Errorf("[ERR] %s", err)
+	}
+
+	if err := d.Set("rules", flattenDataSourceFirewallRules(rules)); err != nil {
+		return diag.Errorf("[ERR] error setting the rules: %s", err)
+	}
 
 	return nil
 }
+
+// flattenDataSourceFirewallRules flattens the rules of both directions, in the order of the API
+func flattenDataSourceFirewallRules(rules []civogo.FirewallRule) []interface{} {
+	flattenedRules := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		flattenedRules = append(flattenedRules, map[string]interface{}{
+			"id":         rule.ID,
+			"direction":  rule.Direction,
+			"label":      rule.Label,
+			"protocol":   rule.Protocol,
+			"port_range": firewallRulePorts(rule),
+			"cidr":       rule.Cidr,
+			"action":     rule.Action,
+		})
+	}
+	return flattenedRules
+}
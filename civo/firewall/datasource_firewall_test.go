@@ -21,6 +21,10 @@ func TestAccDataSourceCivoFirewall_basic(t *testing.T) {
 				Config: DataSourceCivoFirewallConfig(name),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(datasourceName, "name", name),
+					resource.TestCheckResourceAttrPair(datasourceName, "network_id", "civo_firewall.foobar", "network_id"),
+					resource.TestCheckResourceAttrSet(datasourceName, "rules.#"),
+					resource.TestCheckResourceAttr(datasourceName, "instance_count", "0"),
+					resource.TestCheckResourceAttrPair("data.civo_firewall.by_id", "name", "civo_firewall.foobar", "name"),
 				),
 			},
 		},
@@ -37,5 +41,10 @@ data "civo_firewall" "foobar" {
 	name = civo_firewall.foobar.name
 	region = "LON1"
 }
+
+data "civo_firewall" "by_id" {
+	id = civo_firewall.foobar.id
+	region = "LON1"
+}
 `, name)
 }
@@ -0,0 +1,133 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceFirewalls Data source to get and filter all firewalls in a region
+func DataSourceFirewalls() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		Description: "Get information on firewalls for use in other resources or security audits, with the ability to filter and sort the results. " +
+			"`network_id` and `name_prefix` narrow the firewalls down before the filters are applied. If nothing is specified, all firewalls in the region will be returned.",
+		RecordSchema: firewallsSchema(),
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If used, all firewalls will be from the provided region",
+			},
+			"network_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "If used, only the firewalls of this network will be returned",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If used, only the firewalls whose name starts with this prefix will be returned",
+			},
+		},
+		ResultAttributeName: "firewalls",
+		FlattenRecord:       flattenDataSourceFirewalls,
+		GetRecords:          getDataSourceFirewalls,
+	}
+
+	return datalist.NewResource(dataListConfig)
+}
+
+func getDataSourceFirewalls(m interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	region, ok := extra["region"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to find `region` key from query data")
+	}
+
+	if region != "" {
+		apiClient.Region = region
+	}
+
+	networkID, _ := extra["network_id"].(string)
+	namePrefix, _ := extra["name_prefix"].(string)
+
+	allFirewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("[ERR] error retrieving firewalls: %s", err)
+	}
+
+	firewalls := []interface{}{}
+	for _, firewall := range allFirewalls {
+		if networkID != "" && firewall.NetworkID != networkID {
+			continue
+		}
+		if !strings.HasPrefix(firewall.Name, namePrefix) {
+			continue
+		}
+		firewalls = append(firewalls, firewall)
+	}
+
+	return firewalls, nil
+}
+
+func flattenDataSourceFirewalls(firewall, m interface{}, _ map[string]interface{}) (map[string]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	f := firewall.(civogo.Firewall)
+
+	flattenedFirewall := map[string]interface{}{}
+	flattenedFirewall["id"] = f.ID
+	flattenedFirewall["name"] = f.Name
+	flattenedFirewall["network_id"] = f.NetworkID
+	flattenedFirewall["region"] = apiClient.Region
+	flattenedFirewall["rules_count"] = f.RulesCount
+	flattenedFirewall["instance_count"] = f.InstanceCount
+	flattenedFirewall["cluster_count"] = f.ClusterCount
+	flattenedFirewall["loadbalancer_count"] = f.LoadBalancerCount
+
+	return flattenedFirewall, nil
+}
+
+func firewallsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "ID of the firewall",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the firewall",
+		},
+		"network_id": {
+			Type:        schema.TypeString,
+			Description: "ID of the network of the firewall",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Region of the firewall",
+		},
+		"rules_count": {
+			Type:        schema.TypeInt,
+			Description: "Number of rules of the firewall",
+		},
+		"instance_count": {
+			Type:        schema.TypeInt,
+			Description: "Number of instances using the firewall",
+		},
+		"cluster_count": {
+			Type:        schema.TypeInt,
+			Description: "Number of Kubernetes clusters using the firewall",
+		},
+		"loadbalancer_count": {
+			Type:        schema.TypeInt,
+			Description: "Number of load balancers using the firewall",
+		},
+	}
+}
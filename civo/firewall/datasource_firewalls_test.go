@@ -0,0 +1,66 @@
+package firewall_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoFirewalls_basic(t *testing.T) {
+	datasourceName := "data.civo_firewalls.result"
+	prefix := acctest.RandomWithPrefix("fw-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoFirewallsConfig(prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "firewalls.#", "2"),
+					resource.TestCheckResourceAttrPair(datasourceName, "firewalls.0.id", "civo_firewall.a", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "firewalls.1.id", "civo_firewall.b", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "firewalls.0.network_id", "civo_network.foobar", "id"),
+					resource.TestCheckResourceAttr(datasourceName, "firewalls.0.instance_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoFirewallsConfig(prefix string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%[1]s"
+	region = "LON1"
+}
+
+resource "civo_firewall" "a" {
+	name = "%[1]s-a"
+	network_id = civo_network.foobar.id
+	region = "LON1"
+}
+
+resource "civo_firewall" "b" {
+	name = "%[1]s-b"
+	network_id = civo_network.foobar.id
+	region = "LON1"
+}
+
+data "civo_firewalls" "result" {
+	region = "LON1"
+	network_id = civo_network.foobar.id
+	name_prefix = "%[1]s-"
+
+	sort {
+		key = "name"
+		direction = "asc"
+	}
+
+	depends_on = [civo_firewall.a, civo_firewall.b]
+}
+`, prefix)
+}
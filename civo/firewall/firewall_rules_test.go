@@ -0,0 +1,202 @@
+package firewall
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ruleBlock returns an ingress_rule or egress_rule block as the configuration gives it
+func ruleBlock(label, portRange string, portRanges []string, cidr string) map[string]interface{} {
+	ranges := schema.NewSet(schema.HashString, []interface{}{})
+	for _, r := range portRanges {
+		ranges.Add(r)
+	}
+	return map[string]interface{}{
+		"id":          "",
+		"label":       label,
+		"protocol":    "tcp",
+		"port_range":  portRange,
+		"port_ranges": ranges,
+		"cidr":        schema.NewSet(schema.HashString, []interface{}{cidr}),
+		"action":      "allow",
+	}
+}
+
+// apiRule returns a rule as the API returns it
+func apiRule(id, direction, label, ports, cidr string) civogo.FirewallRule {
+	return civogo.FirewallRule{
+		ID:        id,
+		Direction: direction,
+		Label:     label,
+		Protocol:  "tcp",
+		Ports:     ports,
+		Cidr:      []string{cidr},
+		Action:    "allow",
+	}
+}
+
+func TestDiffFirewallRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		current  []civogo.FirewallRule
+		ingress  []interface{}
+		egress   []interface{}
+		toDelete []string
+		toCreate []string
+	}{
+		{
+			name:    "unchanged rule",
+			current: []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			ingress: []interface{}{ruleBlock("web", "80", nil, "0.0.0.0/0")},
+		},
+		{
+			name:    "single port range",
+			current: []civogo.FirewallRule{apiRule("1", "ingress", "web", "80-80", "0.0.0.0/0")},
+			ingress: []interface{}{ruleBlock("web", "80", nil, "0.0.0.0/0")},
+		},
+		{
+			name:     "changed cidr",
+			current:  []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			ingress:  []interface{}{ruleBlock("web", "80", nil, "10.0.0.0/8")},
+			toDelete: []string{"1"},
+			toCreate: []string{"ingress web 80 10.0.0.0/8"},
+		},
+		{
+			name:     "changed direction",
+			current:  []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			egress:   []interface{}{ruleBlock("web", "80", nil, "0.0.0.0/0")},
+			toDelete: []string{"1"},
+			toCreate: []string{"egress web 80 0.0.0.0/0"},
+		},
+		{
+			name: "port_ranges stored",
+			current: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "0.0.0.0/0"),
+				apiRule("2", "ingress", "web", "443", "0.0.0.0/0"),
+			},
+			ingress: []interface{}{ruleBlock("web", "", []string{"443", "80-80"}, "0.0.0.0/0")},
+		},
+		{
+			name: "port_ranges changed",
+			current: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "0.0.0.0/0"),
+				apiRule("2", "ingress", "web", "443", "0.0.0.0/0"),
+			},
+			ingress:  []interface{}{ruleBlock("web", "", []string{"80", "8000-8100"}, "0.0.0.0/0")},
+			toDelete: []string{"2"},
+			toCreate: []string{"ingress web 8000-8100 0.0.0.0/0"},
+		},
+		{
+			name: "duplicate rule",
+			current: []civogo.FirewallRule{
+				apiRule("1", "ingress", "", "22", "0.0.0.0/0"),
+				apiRule("2", "ingress", "", "22", "0.0.0.0/0"),
+			},
+			ingress:  []interface{}{ruleBlock("", "22", nil, "0.0.0.0/0")},
+			toDelete: []string{"2"},
+		},
+	}
+
+	for _, c := range cases {
+		toDelete, toCreate := diffFirewallRules(c.current, c.ingress, c.egress)
+
+		deleted := []string{}
+		for _, rule := range toDelete {
+			deleted = append(deleted, rule.ID)
+		}
+		created := []string{}
+		for _, pending := range toCreate {
+			created = append(created, fmt.Sprintf("%s %s %s %s", pending.direction, pending.rule["label"], pending.rule["port_range"],
+				strings.Join(expandFirewallRuleCIDR(pending.rule["cidr"].(*schema.Set).List()), ",")))
+		}
+		sort.Strings(created)
+
+		if strings.Join(deleted, " ") != strings.Join(c.toDelete, " ") {
+			t.Errorf("%s: diffFirewallRules deletes %v, want %v", c.name, deleted, c.toDelete)
+		}
+		if strings.Join(created, "; ") != strings.Join(c.toCreate, "; ") {
+			t.Errorf("%s: diffFirewallRules creates %v, want %v", c.name, created, c.toCreate)
+		}
+	}
+}
+
+func TestFlattenFirewallRules(t *testing.T) {
+	cases := []struct {
+		name   string
+		rules  []civogo.FirewallRule
+		prior  []interface{}
+		blocks []string
+	}{
+		{
+			name:   "single rule",
+			rules:  []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			blocks: []string{"web 80 []"},
+		},
+		{
+			name: "other direction left out",
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "0.0.0.0/0"),
+				apiRule("2", "egress", "all", "1-65535", "0.0.0.0/0"),
+			},
+			blocks: []string{"web 80 []"},
+		},
+		{
+			name: "labelled rules grouped",
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80-80", "0.0.0.0/0"),
+				apiRule("2", "ingress", "web", "443", "0.0.0.0/0"),
+			},
+			blocks: []string{"web  [443 80]"},
+		},
+		{
+			name: "labelled rules for other cidrs",
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "0.0.0.0/0"),
+				apiRule("2", "ingress", "web", "443", "10.0.0.0/8"),
+			},
+			blocks: []string{"web 443 []", "web 80 []"},
+		},
+		{
+			name: "unlabelled rules",
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "", "80", "0.0.0.0/0"),
+				apiRule("2", "ingress", "", "443", "0.0.0.0/0"),
+			},
+			blocks: []string{" 443 []", " 80 []"},
+		},
+		{
+			name:   "single range of port_ranges",
+			rules:  []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			prior:  []interface{}{ruleBlock("web", "", []string{"80"}, "0.0.0.0/0")},
+			blocks: []string{"web  [80]"},
+		},
+	}
+
+	for _, c := range cases {
+		blocks := []string{}
+		for _, v := range flattenFirewallRules(c.rules, "ingress", c.prior) {
+			block := v.(map[string]interface{})
+			portRanges := []string{}
+			for _, r := range block["port_ranges"].(*schema.Set).List() {
+				portRanges = append(portRanges, r.(string))
+			}
+			sort.Strings(portRanges)
+			blocks = append(blocks, fmt.Sprintf("%s %s %v", block["label"], block["port_range"], portRanges))
+		}
+		sort.Strings(blocks)
+
+		if !reflect.DeepEqual(blocks, c.blocks) {
+			t.Errorf("%s: flattenFirewallRules returned %q, want %q", c.name, blocks, c.blocks)
+		}
+	}
+
+	if rules := flattenFirewallRules(nil, "ingress", nil); rules != nil {
+		t.Errorf("flattenFirewallRules(nil) returned %v, want nil", rules)
+	}
+}
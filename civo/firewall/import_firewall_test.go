@@ -0,0 +1,66 @@
+package firewall_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCivoFirewall_importByName(t *testing.T) {
+	resourceName := "civo_firewall.foobar"
+	firewallName := acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigWithIngressEgress(firewallName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     firewallName,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("LOCAL:%s", firewallName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_importDefaultRules(t *testing.T) {
+	resourceName := "civo_firewall.foobar"
+	firewallName := acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigBasic(firewallName),
+			},
+			{
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateId:      firewallName,
+				ImportStatePersist: true,
+			},
+			{
+				// the default rules are imported as ingress_rule blocks, so the first plan is empty
+				// rather than replacing the firewall or destroying its rules
+				Config:   CivoFirewallConfigBasic(firewallName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
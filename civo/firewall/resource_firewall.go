@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/civo/civogo"
@@ -19,7 +21,7 @@ import (
 func ResourceFirewall() *schema.Resource {
 	return &schema.Resource{
 		Description: "Provides a Civo firewall resource. This can be used to create, modify, and delete firewalls.",
-		Schema: map[string]*schema.Schema{
+		Schema: withFirewallUsage(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -41,17 +43,33 @@ func ResourceFirewall() *schema.Resource {
 				Description: "The firewall region, if is not defined we use the global defined in the provider",
 			},
 			"create_default_rules": {
-				Type:        schema.TypeBool,
-				Default:     true,
-				Optional:    true,
-				ForceNew:    true,
-				Description: "The create rules flag is used to create the default firewall rules, if is not defined will be set to true, and if you set to false you need to define at least one ingress or egress rule",
+				Type:             schema.TypeBool,
+				Default:          true,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressImportedDefaultRules,
+				Description:      "The create rules flag is used to create the default firewall rules, if is not defined will be set to true. Set it to false to start from an empty firewall, which denies all ingress traffic, and declare every rule in terraform, with ingress_rule and egress_rule or civo_firewall_rule resources. Needs to be false if custom rules are set.",
+			},
+			"prevent_destroy_if_attached": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"reassign_on_destroy"},
+				Description:   "If set to true, deleting the firewall fails straight away with the list of the instances, Kubernetes clusters and load balancers still using it (default: false). Conflicts with `reassign_on_destroy`",
+			},
+			"reassign_on_destroy": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"prevent_destroy_if_attached"},
+				Description:   "If set to true, the instances, Kubernetes clusters and load balancers still using the firewall are moved to the default firewall of its network before it's deleted (default: false). Conflicts with `prevent_destroy_if_attached`",
 			},
 			"ingress_rule": {
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
 				Elem:        firewallRuleSchema(),
+				Set:         firewallRuleHash,
 				Description: "The ingress rules, this is a list of rules that will be applied to the firewall",
 			},
 			"egress_rule": {
@@ -59,9 +77,17 @@ func ResourceFirewall() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 				Elem:        firewallRuleSchema(),
+				Set:         firewallRuleHash,
 				Description: "The egress rules, this is a list of rules that will be applied to the firewall",
 			},
-		},
+			// Computed resource
+			"effective_rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        effectiveRuleSchema(),
+				Description: "The rules of the firewall as the API reports them, ingress and egress together in the order the API returns them",
+			},
+		}),
 		CreateContext: resourceFirewallCreate,
 		ReadContext:   resourceFirewallRead,
 		UpdateContext: resourceFirewallUpdate,
@@ -78,32 +104,26 @@ func ResourceFirewall() *schema.Resource {
 				}
 			}
 
-			ingressRules := diff.Get("ingress_rule")
-			for _, v := range ingressRules.(*schema.Set).List() {
-				ingress := v.(map[string]interface{})
-				protocol := ingress["protocol"]
-
-				port := ingress["port_range"]
-				if protocol != "icmp" && port == "" {
-					return fmt.Errorf("ports of ingress rules is required if protocol is tcp or udp")
+			for _, direction := range []string{"ingress", "egress"} {
+				for _, v := range diff.Get(direction + "_rule").(*schema.Set).List() {
+					rule := v.(map[string]interface{})
+					if err := validateRuleBlockPorts(rule); err != nil {
+						return fmt.Errorf("invalid %s rule %q: %s", direction, rule["label"], err)
+					}
 				}
-			}
 
-			egressRules := diff.Get("egress_rule")
-			for _, v := range egressRules.(*schema.Set).List() {
-				egress := v.(map[string]interface{})
-				protocol := egress["protocol"]
-
-				port := egress["port_range"]
-				if protocol != "icmp" && port == "" {
-					return fmt.Errorf("ports of egress rules is required if protocol is tcp or udp")
+				if !diff.HasChange(direction + "_rule") {
+					continue
+				}
+				if label := duplicateRuleLabel(diff.Get(direction + "_rule").(*schema.Set).List()); label != "" {
+					return fmt.Errorf("the label %q is used by more than one %s rule, the labels of the rules must be unique to tell them apart", label, direction)
 				}
 			}
 
 			return nil
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			State: resourceFirewallImport,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
@@ -122,24 +142,6 @@ func resourceFirewallCreate(ctx context.Context, d *schema.ResourceData, m inter
 		apiClient.Region = region.(string)
 	}
 
-	createDefaultRules := d.Get("create_default_rules").(bool)
-
-	// Added verification
-	if !createDefaultRules {
-		var ingressRules bool
-		var egressRules bool
-
-		if _, ok := d.GetOk("ingress_rule"); ok {
-			ingressRules = ok
-		}
-		if _, ok := d.GetOk("egress_rule"); ok {
-			egressRules = ok
-		}
-		if !ingressRules && !egressRules {
-			return diag.Errorf("if you set create_default_rules to false you need to define at least one ingress or egress rule")
-		}
-	}
-
 	log.Printf("[INFO] creating a new firewall %s", d.Get("name").(string))
 
 	firewallConfig, err := firewallRequestBuild(d, apiClient)
@@ -203,21 +205,97 @@ func resourceFirewallRead(_ context.Context, d *schema.ResourceData, m interface
 	d.Set("region", apiClient.Region)
 	d.Set("create_default_rules", d.Get("create_default_rules").(bool))
 
-	for _, rule := range resp.Rules {
-		if rule.Direction == "ingress" {
-			if err := d.Set("ingress_rule", flattenFirewallRules(resp.Rules, rule.Direction)); err != nil {
-				return diag.Errorf("[ERR] error setting ingress rules: %s", err)
-			}
-		} else {
-			if err := d.Set("egress_rule", flattenFirewallRules(resp.Rules, rule.Direction)); err != nil {
-				return diag.Errorf("[ERR] error setting egress rules: %s", err)
-			}
-		}
+	if err := d.Set("effective_rules", flattenEffectiveRules(resp.Rules)); err != nil {
+		return diag.Errorf("[ERR] error setting effective rules: %s", err)
+	}
+
+	// both directions are always set, so rules removed outside of terraform show up as drift
+	if err := d.Set("ingress_rule", flattenFirewallRules(resp.Rules, "ingress", d.Get("ingress_rule").(*schema.Set).List())); err != nil {
+		return diag.Errorf("[ERR] error setting ingress rules: %s", err)
+	}
+	if err := d.Set("egress_rule", flattenFirewallRules(resp.Rules, "egress", d.Get("egress_rule").(*schema.Set).List())); err != nil {
+		return diag.Errorf("[ERR] error setting egress rules: %s", err)
+	}
+
+	if err := setFirewallUsage(d, apiClient, resp); err != nil {
+		return diag.Errorf("[ERR] %s", err)
 	}
 
 	return nil
 }
 
+// custom import to support looking the firewall up by name, e.g. www or LON1:www, its rules are
+// adopted as ingress_rule and egress_rule blocks by the read that follows
+func resourceFirewallImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	apiClient := m.(*civogo.Client)
+
+	search := d.Id()
+	if strings.Contains(search, ":") {
+		region, rest, err := utils.ResourceCommonParseID(search)
+		if err != nil {
+			return nil, err
+		}
+		apiClient.Region = region
+		d.Set("region", region)
+		search = rest
+	}
+
+	firewall, err := findFirewallByIDOrName(apiClient, search)
+	if err != nil {
+		return nil, fmt.Errorf("[ERR] %s", err)
+	}
+
+	log.Printf("[INFO] importing the firewall %s (%s) with its %d rules", firewall.Name, firewall.ID, len(firewall.Rules))
+	d.SetId(firewall.ID)
+
+	// the rules come from the existing firewall, so they are managed as inline blocks from now on
+	d.Set("create_default_rules", false)
+	d.Set("prevent_destroy_if_attached", false)
+	d.Set("reassign_on_destroy", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressImportedDefaultRules keeps create_default_rules from replacing a firewall that already
+// has rules, e.g. an imported one which gets false: the flag only matters when the firewall is
+// created, and its rules are in ingress_rule and egress_rule from then on
+func suppressImportedDefaultRules(_, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" || old != "false" || new != "true" {
+		return false
+	}
+
+	return d.Get("ingress_rule").(*schema.Set).Len() > 0 || d.Get("egress_rule").(*schema.Set).Len() > 0
+}
+
+// findFirewallByIDOrName returns the firewall with exactly this ID or name in the current region,
+// unlike FindFirewall a part of a name doesn't match, and a name used by several firewalls is an error
+func findFirewallByIDOrName(apiClient *civogo.Client, search string) (*civogo.Firewall, error) {
+	firewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the firewalls: %s", err)
+	}
+
+	var found []civogo.Firewall
+	for _, firewall := range firewalls {
+		if firewall.ID == search {
+			found = []civogo.Firewall{firewall}
+			break
+		}
+		if firewall.Name == search {
+			found = append(found, firewall)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unable to find a firewall with the ID or name %s in the region %s", search, apiClient.Region)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("there are %d firewalls named %s in the region %s, please use its ID", len(found), search, apiClient.Region)
+	}
+}
+
 // function to update the firewall
 func resourceFirewallUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
@@ -258,53 +336,29 @@ func resourceFirewallUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			return diag.Errorf("[ERR] an error occurred while trying to list the firewall rules, %s", err)
 		}
 
-		// remove the rules that are not in terraform
-		for _, rule := range allRules {
-			if rule.Direction == "ingress" {
-				if !ingressRulesContains(ingressRules, rule) {
-					log.Printf("[INFO] removing the ingress rule %s", rule.ID)
-					_, err := apiClient.DeleteFirewallRule(d.Id(), rule.ID)
-					if err != nil {
-						return diag.Errorf("[WARN] an error occurred while trying to delete the ingress rule %s, %s", rule.ID, err)
-					}
-				}
-			} else {
-				if !egressRulesContains(egressRules, rule) {
-					log.Printf("[INFO] removing the egress rule %s", rule.ID)
-					_, err := apiClient.DeleteFirewallRule(d.Id(), rule.ID)
-					if err != nil {
-						return diag.Errorf("[WARN] an error occurred while trying to delete the egress rule %s, %s", rule.ID, err)
-					}
-				}
-			}
-		}
+		// only the rules which changed are touched, the API has no call to update a rule or to
+		// create several at once, so a changed rule is deleted and created again
+		toDelete, toCreate := diffFirewallRules(allRules, ingressRules, egressRules)
+		log.Printf("[INFO] updating the rules of the firewall %s, %d to delete, %d to create and %d unchanged",
+			d.Id(), len(toDelete), len(toCreate), len(allRules)-len(toDelete))
 
-		// add the new ingressRules that are not in the current rules
-		if len(ingressRules) > 0 {
-			for _, ingressRule := range ingressRules {
-				if ingressRule.(map[string]interface{})["id"] == "" {
-					fwRule := firewallUpdateBuild(ingressRule, apiClient.Region, "ingress", d)
-					resp, err := apiClient.NewFirewallRule(fwRule)
-					if err != nil {
-						return diag.Errorf("[WARN] an error occurred while trying to create the ingress rule %s, %s", fwRule, err)
-					}
-					log.Printf("[INFO] creating a new ingress rule %s", resp.ID)
-				}
+		// remove the rules that are not in terraform
+		for _, rule := range toDelete {
+			log.Printf("[INFO] removing the %s rule %s", rule.Direction, rule.ID)
+			_, err := apiClient.DeleteFirewallRule(d.Id(), rule.ID)
+			if err != nil {
+				return diag.Errorf("[WARN] an error occurred while trying to delete the %s rule %s, %s", rule.Direction, rule.ID, err)
 			}
 		}
 
-		// add the new egressRules that are not in the current rules
-		if len(egressRules) > 0 {
-			for _, egressRule := range egressRules {
-				if egressRule.(map[string]interface{})["id"] == "" {
-					fwRule := firewallUpdateBuild(egressRule, apiClient.Region, "egress", d)
-					resp, err := apiClient.NewFirewallRule(fwRule)
-					if err != nil {
-						return diag.Errorf("[WARN] an error occurred while trying to create the egress rule %s, %s", fwRule, err)
-					}
-					log.Printf("[INFO] creating a new egress rule %s", resp.ID)
-				}
+		// add the rules that are not in the current rules
+		for _, rule := range toCreate {
+			fwRule := firewallUpdateBuild(rule.rule, apiClient.Region, rule.direction, d)
+			resp, err := apiClient.NewFirewallRule(fwRule)
+			if err != nil {
+				return diag.Errorf("[WARN] an error occurred while trying to create the %s rule %s, %s", rule.direction, fwRule, err)
 			}
+			log.Printf("[INFO] creating a new %s rule %s", rule.direction, resp.ID)
 		}
 	}
 
@@ -322,12 +376,33 @@ func resourceFirewallDelete(_ context.Context, d *schema.ResourceData, m interfa
 
 	firewallID := d.Id()
 	log.Printf("[INFO] Checking if firewall %s exists", firewallID)
-	_, err := apiClient.FindFirewall(firewallID)
+	firewall, err := apiClient.FindFirewall(firewallID)
 	if err != nil {
 		log.Printf("[INFO] Unable to find firewall %s - probably it's been deleted", firewallID)
 		return nil
 	}
 
+	if d.Get("prevent_destroy_if_attached").(bool) || d.Get("reassign_on_destroy").(bool) {
+		usage, err := getFirewallUsage(apiClient, firewall)
+		if err != nil {
+			return diag.Errorf("[ERR] failed to list the resources using the firewall %s: %s", firewallID, err)
+		}
+
+		if !usage.empty() && d.Get("prevent_destroy_if_attached").(bool) {
+			return diag.Errorf("[ERR] the firewall %s can't be deleted while it's used by %s (prevent_destroy_if_attached is set)", firewallID, usage)
+		}
+
+		if !usage.empty() && d.Get("reassign_on_destroy").(bool) {
+			defaultFirewall, err := findNetworkDefaultFirewall(apiClient, firewall)
+			if err != nil {
+				return diag.Errorf("[ERR] unable to move the resources using the firewall %s: %s", firewallID, err)
+			}
+			if err := reassignFirewallUsage(apiClient, usage, defaultFirewall.ID); err != nil {
+				return diag.Errorf("[ERR] %s", err)
+			}
+		}
+	}
+
 	log.Printf("[INFO] deleting the firewall %s", firewallID)
 
 	deleteStateConf := &retry.StateChangeConf{
@@ -353,24 +428,52 @@ func resourceFirewallDelete(_ context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
-// ingressRulesContains check if the ingress rules contains the rule
-func ingressRulesContains(ingressRules []interface{}, rule civogo.FirewallRule) bool {
-	for _, ingressRule := range ingressRules {
-		if ingressRule.(map[string]interface{})["id"] == rule.ID {
-			return true
+// pendingFirewallRule is a rule of the configuration to create in the firewall
+type pendingFirewallRule struct {
+	direction string
+	rule      map[string]interface{}
+}
+
+// diffFirewallRules matches the rules of the configuration with the current rules of the firewall
+// by what they do rather than by ID, like firewallRuleHash, so a rule which didn't change is left
+// alone even if its ID isn't known, e.g. after being recreated outside of terraform. It returns the
+// current rules to delete and the rules of the configuration to create
+func diffFirewallRules(current []civogo.FirewallRule, ingressRules, egressRules []interface{}) ([]civogo.FirewallRule, []pendingFirewallRule) {
+	unmatched := map[string][]civogo.FirewallRule{}
+	for _, rule := range current {
+		key := rule.Direction + "-" + firewallRuleKey(flattenFirewallRule(rule))
+		unmatched[key] = append(unmatched[key], rule)
+	}
+
+	kept := map[string]bool{}
+	toCreate := []pendingFirewallRule{}
+	for _, direction := range []string{"ingress", "egress"} {
+		rules := ingressRules
+		if direction == "egress" {
+			rules = egressRules
+		}
+
+		for _, v := range rules {
+			for _, rule := range expandRuleBlock(v.(map[string]interface{})) {
+				key := direction + "-" + firewallRuleKey(rule)
+				if len(unmatched[key]) > 0 {
+					kept[unmatched[key][0].ID] = true
+					unmatched[key] = unmatched[key][1:]
+					continue
+				}
+				toCreate = append(toCreate, pendingFirewallRule{direction: direction, rule: rule})
+			}
 		}
 	}
-	return false
-}
 
-// egressRulesContains check if the egress rules contains the rule
-func egressRulesContains(egressRules []interface{}, rule civogo.FirewallRule) bool {
-	for _, egressRule := range egressRules {
-		if egressRule.(map[string]interface{})["id"] == rule.ID {
-			return true
+	toDelete := []civogo.FirewallRule{}
+	for _, rule := range current {
+		if !kept[rule.ID] {
+			toDelete = append(toDelete, rule)
 		}
 	}
-	return false
+
+	return toDelete, toCreate
 }
 
 func firewallRuleSchema() *schema.Resource {
@@ -387,7 +490,7 @@ func firewallRuleSchema() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				// Computed:     true,
-				Description:  "A string that will be the displayed name/reference for this rule",
+				Description:  "A string that will be the displayed name/reference for this rule, e.g. `allow-prometheus-scrape`. It's stored in the API and must be unique among the rules of the same direction",
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"protocol": {
@@ -405,8 +508,20 @@ func firewallRuleSchema() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				// Computed:     true,
-				Description:  "The port or port range to open, can be a single port or a range separated by a dash (`-`), e.g. `80` or `80-443`",
-				ValidateFunc: validation.NoZeroValues,
+				Description:  "The port or port range to open, can be a single port or a range separated by a dash (`-`), e.g. `80` or `80-443`, with ports between 1 and 65535. Required if the protocol is `tcp` or `udp`, and can't be set if it's `icmp`",
+				ValidateFunc: utils.ValidatePortRange,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return normalizePortRange(old) == normalizePortRange(new)
+				},
+			},
+			"port_ranges": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Several ports or port ranges to open for the same CIDRs, e.g. `[\"80\", \"443\", \"8000-8100\"]`, instead of `port_range`. The API stores one rule per range, they are read back as this block. The rule needs a `label` so its ranges can be told apart from other rules",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: utils.ValidatePortRange,
+				},
 			},
 			"cidr": {
 				Type:        schema.TypeSet,
@@ -414,7 +529,7 @@ func firewallRuleSchema() *schema.Resource {
 				Description: "The CIDR notation of the other end to affect, or a valid network CIDR (e.g. 0.0.0.0/0 to open for everyone or 1.2.3.4/32 to open just for a specific IP address)",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.NoZeroValues,
+					ValidateFunc: utils.ValidateFirewallCIDR,
 				},
 			},
 			"action": {
@@ -429,6 +544,190 @@ func firewallRuleSchema() *schema.Resource {
 	}
 }
 
+// firewallRuleHash hashes a rule by what it does, so the same rule gets the same hash whatever
+// the order of the rules in the configuration or in the API, and the computed id is left out.
+// The ports are normalized, so 80 and 80-80 are the same rule
+func firewallRuleHash(v interface{}) int {
+	return schema.HashString(firewallRuleKey(v.(map[string]interface{})))
+}
+
+// firewallRuleKey describes what a rule does, its label included, for firewallRuleHash and diffFirewallRules
+func firewallRuleKey(rule map[string]interface{}) string {
+	return firewallRuleGroupKey(rule) + strings.Join(rulePortRanges(rule), ",") + "-"
+}
+
+// firewallRuleGroupKey describes what a rule does but its ports, the rules stored for the
+// port_ranges of a block have the same one
+func firewallRuleGroupKey(rule map[string]interface{}) string {
+	cidrs := []string{}
+	if cidr, ok := rule["cidr"].(*schema.Set); ok {
+		cidrs = expandFirewallRuleCIDR(cidr.List())
+	}
+	sort.Strings(cidrs)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s-", rule["label"])
+	fmt.Fprintf(&buf, "%s-", strings.ToLower(fmt.Sprint(rule["protocol"])))
+	fmt.Fprintf(&buf, "%s-", strings.Join(cidrs, ","))
+	fmt.Fprintf(&buf, "%s-", rule["action"])
+
+	return buf.String()
+}
+
+// rulePortRanges returns the normalized and sorted port ranges of a block, from port_ranges or
+// else from port_range
+func rulePortRanges(rule map[string]interface{}) []string {
+	portRanges := []string{}
+	if set, ok := rule["port_ranges"].(*schema.Set); ok {
+		for _, v := range set.List() {
+			portRanges = append(portRanges, normalizePortRange(v.(string)))
+		}
+	}
+	if len(portRanges) == 0 {
+		return []string{normalizePortRange(fmt.Sprint(rule["port_range"]))}
+	}
+
+	sort.Strings(portRanges)
+	return portRanges
+}
+
+// validateRuleBlockPorts checks the ports of a block against its protocol
+func validateRuleBlockPorts(rule map[string]interface{}) error {
+	portRanges, ok := rule["port_ranges"].(*schema.Set)
+	if !ok || portRanges.Len() == 0 {
+		return utils.ValidateFirewallRulePorts(rule["protocol"].(string), rule["port_range"].(string))
+	}
+
+	if rule["port_range"].(string) != "" {
+		return fmt.Errorf("port_range and port_ranges can't both be set")
+	}
+	if rule["protocol"].(string) == "icmp" {
+		return fmt.Errorf("port_ranges can't be set when protocol is icmp, icmp has no ports")
+	}
+	if rule["label"].(string) == "" {
+		return fmt.Errorf("a label is required with port_ranges, to read the rules stored for its ranges back as one rule")
+	}
+
+	return nil
+}
+
+// expandRuleBlock returns the rules to store for a block, one per range of its port_ranges
+func expandRuleBlock(rule map[string]interface{}) []map[string]interface{} {
+	portRanges, ok := rule["port_ranges"].(*schema.Set)
+	if !ok || portRanges.Len() == 0 {
+		return []map[string]interface{}{rule}
+	}
+
+	rules := []map[string]interface{}{}
+	for _, portRange := range rulePortRanges(rule) {
+		expanded := map[string]interface{}{}
+		for k, v := range rule {
+			expanded[k] = v
+		}
+		expanded["port_range"] = portRange
+		expanded["port_ranges"] = schema.NewSet(schema.HashString, []interface{}{})
+		rules = append(rules, expanded)
+	}
+	return rules
+}
+
+// duplicateRuleLabel returns the first label used by more than one of the rules, rules without
+// a label are left out
+func duplicateRuleLabel(rules []interface{}) string {
+	seen := map[string]bool{}
+	for _, v := range rules {
+		label := v.(map[string]interface{})["label"].(string)
+		if label == "" {
+			continue
+		}
+		if seen[label] {
+			return label
+		}
+		seen[label] = true
+	}
+	return ""
+}
+
+// firewallRulePorts returns the port range of a rule as it's configured, icmp has no ports
+// so whatever the API returns for them is left out
+func firewallRulePorts(rule civogo.FirewallRule) string {
+	if rule.Protocol == "icmp" {
+		return ""
+	}
+	return rule.Ports
+}
+
+// normalizePortRange returns the port range without spaces, and a range with the same start
+// and end as a single port, e.g. " 80 - 80 " is 80
+func normalizePortRange(portRange string) string {
+	portRange = strings.ReplaceAll(portRange, " ", "")
+	if start, end, found := strings.Cut(portRange, "-"); found && start == end {
+		return start
+	}
+	return portRange
+}
+
+func effectiveRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the firewall rule",
+			},
+			"direction": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The direction of the rule, `ingress` or `egress`",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The label of the rule",
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The protocol of the rule",
+			},
+			"port_range": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The port or port range of the rule",
+			},
+			"cidr": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The CIDRs the rule applies to",
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The action of the rule, `allow` or `deny`",
+			},
+		},
+	}
+}
+
+// flattenEffectiveRules flattens the rules of the firewall as the API returns them
+func flattenEffectiveRules(rules []civogo.FirewallRule) []interface{} {
+	flattenedRules := []interface{}{}
+	for _, rule := range rules {
+		flattenedRules = append(flattenedRules, map[string]interface{}{
+			"id":         rule.ID,
+			"direction":  rule.Direction,
+			"label":      rule.Label,
+			"protocol":   rule.Protocol,
+			"port_range": rule.Ports,
+			"cidr":       rule.Cidr,
+			"action":     rule.Action,
+		})
+	}
+
+	return flattenedRules
+}
+
 // firewallRequestBuild builds the request body for a firewall
 func firewallRequestBuild(d *schema.ResourceData, client *civogo.Client) (*civogo.FirewallConfig, error) {
 	var networkID string
@@ -468,21 +767,22 @@ func firewallRequestBuild(d *schema.ResourceData, client *civogo.Client) (*civog
 // expandFirewallIngressRules expands the ingress rules
 func expandFirewallRules(rules []interface{}, direction string) []civogo.FirewallRule {
 	var firewallRules []civogo.FirewallRule
-	for _, rule := range rules {
-		rule := rule.(map[string]interface{})
-		fwRule := civogo.FirewallRule{
-			Label:     rule["label"].(string),
-			Protocol:  rule["protocol"].(string),
-			Direction: direction,
-			Cidr:      expandFirewallRuleCIDR(rule["cidr"].(*schema.Set).List()),
-			Action:    rule["action"].(string),
-		}
+	for _, block := range rules {
+		for _, rule := range expandRuleBlock(block.(map[string]interface{})) {
+			fwRule := civogo.FirewallRule{
+				Label:     rule["label"].(string),
+				Protocol:  rule["protocol"].(string),
+				Direction: direction,
+				Cidr:      expandFirewallRuleCIDR(rule["cidr"].(*schema.Set).List()),
+				Action:    rule["action"].(string),
+			}
 
-		if rule["port_range"].(string) != "" {
-			fwRule.Ports = rule["port_range"].(string)
-		}
+			if rule["port_range"].(string) != "" {
+				fwRule.Ports = rule["port_range"].(string)
+			}
 
-		firewallRules = append(firewallRules, fwRule)
+			firewallRules = append(firewallRules, fwRule)
+		}
 	}
 	return firewallRules
 }
@@ -497,35 +797,57 @@ func expandFirewallRuleCIDR(strings []interface{}) []string {
 	return expandedStrings
 }
 
-// flattenFirewallRules flattens the firewall rules
-func flattenFirewallRules(rules []civogo.FirewallRule, direction string) []interface{} {
+// flattenFirewallRules flattens the firewall rules. The rules stored for the port_ranges of a block
+// have the same label, so the labelled rules doing the same but for their ports are read back as
+// one block with port_ranges, and so is a single rule if the prior block used port_ranges
+func flattenFirewallRules(rules []civogo.FirewallRule, direction string, prior []interface{}) []interface{} {
 	if rules == nil {
 		return nil
 	}
 
+	usesPortRanges := map[string]bool{}
+	for _, v := range prior {
+		block := v.(map[string]interface{})
+		if portRanges, ok := block["port_ranges"].(*schema.Set); ok && portRanges.Len() > 0 {
+			usesPortRanges[firewallRuleGroupKey(block)] = true
+		}
+	}
+
 	// We need to do this because our rules come all together
 	// and we need to split them up into ingress and egress rules
-	rulesCount := 0
-	rulesObject := []civogo.FirewallRule{}
+	groups := map[string][]civogo.FirewallRule{}
+	order := []string{}
 	for _, rule := range rules {
-		if rule.Direction == direction {
-			rulesCount++
-			rulesObject = append(rulesObject, rule)
+		if rule.Direction != direction {
+			continue
 		}
+
+		key := rule.ID
+		if rule.Label != "" {
+			key = firewallRuleGroupKey(flattenFirewallRule(rule))
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], rule)
 	}
 
-	log.Printf("[INFO] retriving the firewall rules %+v", rulesObject)
+	flattenedRules := []interface{}{}
+	for _, key := range order {
+		group := groups[key]
+		if len(group) == 1 && !usesPortRanges[key] {
+			flattenedRules = append(flattenedRules, flattenFirewallRule(group[0]))
+			continue
+		}
 
-	flattenedRules := make([]interface{}, rulesCount)
-	for i, rule := range rulesObject {
-		flattenedRules[i] = map[string]interface{}{
-			"id":         rule.ID,
-			"label":      rule.Label,
-			"protocol":   rule.Protocol,
-			"port_range": rule.Ports,
-			"action":     rule.Action,
-			"cidr":       flattenFirewallRuleCIDR(rule.Cidr),
+		portRanges := schema.NewSet(schema.HashString, []interface{}{})
+		for _, rule := range group {
+			portRanges.Add(normalizePortRange(firewallRulePorts(rule)))
 		}
+		block := flattenFirewallRule(group[0])
+		block["port_range"] = ""
+		block["port_ranges"] = portRanges
+		flattenedRules = append(flattenedRules, block)
 	}
 
 	log.Printf("[INFO] retriving the flattenedRules %+v", flattenedRules)
@@ -533,6 +855,19 @@ func flattenFirewallRules(rules []civogo.FirewallRule, direction string) []inter
 	return flattenedRules
 }
 
+// flattenFirewallRule flattens a rule into an ingress_rule or egress_rule block
+func flattenFirewallRule(rule civogo.FirewallRule) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          rule.ID,
+		"label":       rule.Label,
+		"protocol":    rule.Protocol,
+		"port_range":  firewallRulePorts(rule),
+		"action":      rule.Action,
+		"cidr":        flattenFirewallRuleCIDR(rule.Cidr),
+		"port_ranges": schema.NewSet(schema.HashString, []interface{}{}),
+	}
+}
+
 func flattenFirewallRuleCIDR(strings []string) *schema.Set {
 	flattenedStrings := schema.NewSet(schema.HashString, []interface{}{})
 	for _, v := range strings {
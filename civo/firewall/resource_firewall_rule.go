@@ -0,0 +1,313 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceFirewallRule Firewall rule resource, with this we can add a single rule to a firewall
+// managed somewhere else, e.g. in another module
+func ResourceFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Civo firewall rule resource. This can be used to add a single rule to a firewall, which may be defined in another module, and to delete it without touching the other rules of the firewall.",
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "The ID of the firewall the rule belongs to",
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+				Description:  "The direction of the rule, `ingress` or `egress`",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "tcp",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp"}, false),
+				Description:  "The protocol choice from `tcp`, `udp` or `icmp` (the default if unspecified is `tcp`)",
+			},
+			"port_range": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: utils.ValidatePortRange,
+				Description:  "The port or port range to open, can be a single port or a range separated by a dash (`-`), e.g. `80` or `80-443`, with ports between 1 and 65535. Required if the protocol is `tcp` or `udp`, and can't be set if it's `icmp`",
+			},
+			"cidr": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"cidr", "source_firewall_id"},
+				Description:  "The CIDR notation of the other end to affect, or a valid network CIDR (e.g. 0.0.0.0/0 to open for everyone or 1.2.3.4/32 to open just for a specific IP address). One of `cidr` or `source_firewall_id` is required",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: utils.ValidateFirewallCIDR,
+				},
+			},
+			"source_firewall_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"cidr", "source_firewall_id"},
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "The ID of another firewall whose instances and Kubernetes nodes are the other end to affect, like a security group. It's resolved to the private IPs of the instances using the firewall when the rule is created, a later change of the instances doesn't update the rule",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "allow",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+				Description:  "The action of the rule, `allow` or `deny` (the default if unspecified is `allow`)",
+			},
+			"label": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "A string that will be the displayed name/reference for this rule",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region of the firewall, if is not defined we use the global defined in the provider",
+			},
+			// Computed resource
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the rules stored by the API for this rule, the ID of the resource first. There is more than one when the API stores a rule with several CIDRs as one rule per CIDR, they are read back together as this rule",
+			},
+		},
+		CreateContext: resourceFirewallRuleCreate,
+		ReadContext:   resourceFirewallRuleRead,
+		DeleteContext: resourceFirewallRuleDelete,
+		CustomizeDiff: customizeDiffFirewallRule,
+		Importer: &schema.ResourceImporter{
+			State: resourceFirewallRuleImport,
+		},
+	}
+}
+
+// function to create a firewall rule
+func resourceFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if it's defined
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	config := &civogo.FirewallRuleConfig{
+		FirewallID: d.Get("firewall_id").(string),
+		Region:     apiClient.Region,
+		Protocol:   d.Get("protocol").(string),
+		Ports:      d.Get("port_range").(string),
+		Cidr:       expandFirewallRuleCIDR(d.Get("cidr").(*schema.Set).List()),
+		Direction:  d.Get("direction").(string),
+		Action:     d.Get("action").(string),
+		Label:      d.Get("label").(string),
+	}
+
+	if sourceFirewallID, ok := d.GetOk("source_firewall_id"); ok {
+		cidrs, err := firewallMemberCIDRs(apiClient, sourceFirewallID.(string))
+		if err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+		if len(cidrs) == 0 {
+			return diag.Errorf("[ERR] no instance uses the source firewall %s, so there is no IP to create the rule for", sourceFirewallID)
+		}
+		config.Cidr = cidrs
+	}
+
+	// the rules already in the firewall, to tell which ones the API creates for this rule
+	before, err := apiClient.ListFirewallRules(config.FirewallID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to list the rules of the firewall %s: %s", config.FirewallID, err)
+	}
+
+	log.Printf("[INFO] creating a new %s rule in the firewall %s", config.Direction, config.FirewallID)
+	rule, err := apiClient.NewFirewallRule(config)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to create a new rule in the firewall %s: %s", config.FirewallID, err)
+	}
+
+	d.SetId(rule.ID)
+
+	after, err := apiClient.ListFirewallRules(config.FirewallID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to list the rules of the firewall %s: %s", config.FirewallID, err)
+	}
+	ruleIDs := createdRuleIDs(rule.ID, config, before, after)
+	if len(ruleIDs) > 1 {
+		log.Printf("[INFO] the rule %s was stored as %d rules, one per CIDR: %s", rule.ID, len(ruleIDs), strings.Join(ruleIDs, ", "))
+	}
+	d.Set("rule_ids", ruleIDs)
+
+	return resourceFirewallRuleRead(ctx, d, m)
+}
+
+// function to read a firewall rule
+func resourceFirewallRuleRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if it's defined
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	firewallID := d.Get("firewall_id").(string)
+
+	log.Printf("[INFO] retriving the rule %s of the firewall %s", d.Id(), firewallID)
+	rules, err := apiClient.ListFirewallRules(firewallID)
+	if err != nil {
+		if utils.IsNotFoundError(err, civogo.DatabaseFirewallNotFoundError) {
+			log.Printf("[WARN] firewall %s not found, removing the rule %s from state", firewallID, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ERR] failed to list the rules of the firewall %s: %s", firewallID, err)
+	}
+
+	found := findRuleParts(rules, ruleIDs(d))
+	if len(found) == 0 {
+		log.Printf("[WARN] rule %s not found in the firewall %s, removing from state", d.Id(), firewallID)
+		d.SetId("")
+		return nil
+	}
+
+	// the rules stored for the CIDRs of this rule are read back as one rule
+	rule := found[0]
+	cidrs := []string{}
+	ids := []string{}
+	for _, part := range found {
+		cidrs = append(cidrs, part.Cidr...)
+		ids = append(ids, part.ID)
+	}
+
+	d.Set("region", apiClient.Region)
+	d.Set("direction", rule.Direction)
+	d.Set("protocol", rule.Protocol)
+	d.Set("port_range", firewallRulePorts(rule))
+	d.Set("action", rule.Action)
+	d.Set("label", rule.Label)
+	if err := d.Set("cidr", flattenFirewallRuleCIDR(cidrs)); err != nil {
+		return diag.Errorf("[ERR] error setting the rule cidr: %s", err)
+	}
+	d.Set("rule_ids", ids)
+
+	return nil
+}
+
+// function to delete a firewall rule, the other rules of the firewall are left as they are
+func resourceFirewallRuleDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if it's defined
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	firewallID := d.Get("firewall_id").(string)
+
+	for _, ruleID := range ruleIDs(d) {
+		log.Printf("[INFO] deleting the rule %s of the firewall %s", ruleID, firewallID)
+		_, err := apiClient.DeleteFirewallRule(firewallID, ruleID)
+		if err != nil {
+			if utils.IsNotFoundError(err, civogo.DatabaseFirewallNotFoundError) {
+				log.Printf("[INFO] rule %s not found - probably it's been deleted", ruleID)
+				continue
+			}
+			return diag.Errorf("[ERR] an error occurred while trying to delete the rule %s, %s", ruleID, err)
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffFirewallRule checks the ports of the rule, and leaves its CIDRs unknown until the
+// instances using its source firewall are resolved
+func customizeDiffFirewallRule(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if err := utils.ValidateFirewallRulePorts(d.Get("protocol").(string), d.Get("port_range").(string)); err != nil {
+		return err
+	}
+
+	sourceFirewallID := d.Get("source_firewall_id").(string)
+	if sourceFirewallID == "" || !d.NewValueKnown("source_firewall_id") {
+		return nil
+	}
+
+	// the instances may be created in the same apply, so they are only resolved when the rule is created,
+	// listing them on every plan would cost a call per rule
+	if d.Id() == "" || d.HasChange("source_firewall_id") {
+		return d.SetNewComputed("cidr")
+	}
+
+	return nil
+}
+
+// firewallMemberCIDRs returns the private IPs, as /32 CIDRs, of the instances using the firewall,
+// Kubernetes nodes included, sorted
+func firewallMemberCIDRs(apiClient *civogo.Client, firewallID string) ([]string, error) {
+	instances, err := apiClient.ListAllInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the instances using the firewall %s: %s", firewallID, err)
+	}
+
+	cidrs := []string{}
+	for _, instance := range instances {
+		if instance.FirewallID == firewallID && instance.PrivateIP != "" {
+			cidrs = append(cidrs, instance.PrivateIP+"/32")
+		}
+	}
+	sort.Strings(cidrs)
+
+	return cidrs, nil
+}
+
+// custom import to set the firewall of the rule, the ID is firewall_id:rule_id. The rules the API
+// stored for the other CIDRs of the rule are imported with it
+func resourceFirewallRuleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	apiClient := m.(*civogo.Client)
+
+	firewallID, ruleID, err := utils.ResourceCommonParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := apiClient.ListFirewallRules(firewallID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the rules of the firewall %s: %s", firewallID, err)
+	}
+
+	found := findRuleParts(rules, []string{ruleID})
+	if len(found) == 0 {
+		return nil, fmt.Errorf("the rule %s wasn't found in the firewall %s", ruleID, firewallID)
+	}
+
+	d.SetId(ruleID)
+	d.Set("firewall_id", firewallID)
+	d.Set("rule_ids", importedRuleIDs(found[0], rules))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,200 @@
+package firewall_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccCivoFirewallRule_basic(t *testing.T) {
+	resName := "civo_firewall_rule.http"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallRuleConfig(firewallName, true),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallRuleResourceExists(resName),
+					resource.TestCheckResourceAttr(resName, "direction", "ingress"),
+					resource.TestCheckResourceAttr(resName, "protocol", "tcp"),
+					resource.TestCheckResourceAttr(resName, "port_range", "80"),
+					resource.TestCheckResourceAttr(resName, "action", "allow"),
+					resource.TestCheckResourceAttr(resName, "cidr.#", "2"),
+					resource.TestCheckResourceAttrPair(resName, "firewall_id", "civo_firewall.foobar", "id"),
+					resource.TestCheckResourceAttrPair(resName, "rule_ids.0", resName, "id"),
+				),
+			},
+			{
+				ResourceName:      resName,
+				ImportState:       true,
+				ImportStateIdFunc: firewallRuleImportID(resName),
+				ImportStateVerify: true,
+			},
+			{
+				// the CIDRs of the rule are read back as one rule, whatever the number of rules the API stored
+				Config:   CivoFirewallRuleConfig(firewallName, true),
+				PlanOnly: true,
+			},
+			{
+				// removing the rule resource must leave the firewall and its other rules alone
+				Config: CivoFirewallRuleConfig(firewallName, false),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallHasRules("civo_firewall.foobar", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewallRule_sourceFirewall(t *testing.T) {
+	resName := "civo_firewall_rule.app_to_db"
+	var name = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallRuleConfigSourceFirewall(name),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallRuleResourceExists(resName),
+					resource.TestCheckResourceAttrPair(resName, "source_firewall_id", "civo_firewall.app", "id"),
+					resource.TestCheckResourceAttr(resName, "cidr.#", "1"),
+				),
+			},
+			{
+				// the rule keeps the IPs it was created with, so it's left as it is
+				Config:   CivoFirewallRuleConfigSourceFirewall(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// CivoFirewallRuleResourceExists checks the rule is in its firewall
+func CivoFirewallRuleResourceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+		_, err := client.FindFirewallRule(rs.Primary.Attributes["firewall_id"], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Firewall rule not found: (%s) %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+// CivoFirewallHasRules checks how many rules the firewall has in the API
+func CivoFirewallHasRules(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+		rules, err := client.ListFirewallRules(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(rules) != count {
+			return fmt.Errorf("expected %d rules in the firewall %s, got %d", count, rs.Primary.ID, len(rules))
+		}
+
+		return nil
+	}
+}
+
+func firewallRuleImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["firewall_id"], rs.Primary.ID), nil
+	}
+}
+
+func CivoFirewallRuleConfig(name string, withRule bool) string {
+	config := fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+
+	egress_rule {
+		label = "ssh"
+		protocol = "tcp"
+		port_range = "22"
+		cidr = ["192.168.1.1/32"]
+		action = "allow"
+	}
+}`, name)
+
+	if withRule {
+		config += `
+
+resource "civo_firewall_rule" "http" {
+	firewall_id = civo_firewall.foobar.id
+	region = "LOCAL"
+	direction = "ingress"
+	protocol = "tcp"
+	port_range = "80"
+	cidr = ["192.168.1.0/24", "10.0.0.0/8"]
+	label = "http"
+}`
+	}
+
+	return config
+}
+
+func CivoFirewallRuleConfigSourceFirewall(name string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_firewall" "app" {
+	name = "%s-app"
+}
+
+resource "civo_firewall" "db" {
+	name = "%s-db"
+	create_default_rules = false
+}
+
+resource "civo_instance" "app" {
+	hostname = "%s-app"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	firewall_id = civo_firewall.app.id
+}
+
+resource "civo_firewall_rule" "app_to_db" {
+	firewall_id = civo_firewall.db.id
+	direction = "ingress"
+	port_range = "5432"
+	source_firewall_id = civo_firewall.app.id
+	label = "postgres-from-app"
+
+	depends_on = [civo_instance.app]
+}`, name, name, name)
+}
@@ -2,6 +2,8 @@ package firewall_test
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/civo/civogo"
@@ -36,6 +38,7 @@ func TestAccCivoFirewall_basic(t *testing.T) {
 					// verify local values
 					resource.TestCheckResourceAttr(resName, "name", firewallName),
 					resource.TestCheckResourceAttrSet(resName, "ingress_rule.#"),
+					resource.TestCheckResourceAttrSet(resName, "effective_rules.#"),
 				),
 			},
 		},
@@ -113,6 +116,264 @@ func TestAccCivoFirewall_update(t *testing.T) {
 	})
 }
 
+func TestAccCivoFirewall_ruleOrder(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigRuleOrder(firewallName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "2"),
+				),
+			},
+			{
+				// the same rules in another order, with the CIDRs swapped, must not change anything
+				Config:   CivoFirewallConfigRuleOrder(firewallName, true),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_changeOneRule(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+	ruleIDs := map[string]string{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigRuleOrder(firewallName, false),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallRuleIDs(resName, ruleIDs),
+				),
+			},
+			{
+				// only the https rule changes, so the http rule must be left as it is
+				Config: strings.Replace(CivoFirewallConfigRuleOrder(firewallName, false), `"0.0.0.0/0"`, `"10.0.0.0/8"`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "2"),
+					CivoFirewallRuleKept(resName, ruleIDs, "http"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_portRanges(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigPortRanges(firewallName, `["80", "443", "8000-8100"]`),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallHasRules(resName, 3),
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resName, "ingress_rule.*.port_ranges.*", "8000-8100"),
+				),
+			},
+			{
+				// the three rules stored by the API are read back as the block
+				Config:   CivoFirewallConfigPortRanges(firewallName, `["443", "80", "8000-8100"]`),
+				PlanOnly: true,
+			},
+			{
+				// removing a range deletes its rule only
+				Config: CivoFirewallConfigPortRanges(firewallName, `["80", "443"]`),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallHasRules(resName, 2),
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_icmp(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoFirewallConfigICMP(firewallName, `port_range = "80"`),
+				ExpectError: regexp.MustCompile("can't be set when protocol is icmp"),
+			},
+			{
+				Config: CivoFirewallConfigICMP(firewallName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "ingress_rule.*", map[string]string{
+						"label":      "ping",
+						"protocol":   "icmp",
+						"port_range": "",
+					}),
+				),
+			},
+			{
+				Config:   CivoFirewallConfigICMP(firewallName, ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_invalidRule(t *testing.T) {
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoFirewallConfigRule(firewallName, "0-80", "0.0.0.0/0"),
+				ExpectError: regexp.MustCompile("isn't between 1 and 65535"),
+			},
+			{
+				Config:      CivoFirewallConfigRule(firewallName, "443-80", "0.0.0.0/0"),
+				ExpectError: regexp.MustCompile("from the lowest to the highest"),
+			},
+			{
+				Config:      CivoFirewallConfigRule(firewallName, "80", "10.0.0.0/33"),
+				ExpectError: regexp.MustCompile("must be a CIDR"),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_empty(t *testing.T) {
+	var firewall civogo.Firewall
+
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigEmpty(firewallName),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallResourceExists(resName, &firewall),
+					CivoFirewallHasRules(resName, 0),
+					resource.TestCheckResourceAttr(resName, "create_default_rules", "false"),
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "0"),
+					resource.TestCheckResourceAttr(resName, "egress_rule.#", "0"),
+					resource.TestCheckResourceAttr(resName, "effective_rules.#", "0"),
+				),
+			},
+			{
+				Config:   CivoFirewallConfigEmpty(firewallName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_labels(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoFirewallConfigLabels(firewallName, "allow-metrics", "allow-metrics"),
+				ExpectError: regexp.MustCompile("used by more than one ingress rule"),
+			},
+			{
+				Config: CivoFirewallConfigLabels(firewallName, "allow-prometheus-scrape", "allow-node-exporter"),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallHasRuleLabels(resName, "allow-prometheus-scrape", "allow-node-exporter"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "ingress_rule.*", map[string]string{
+						"label":      "allow-prometheus-scrape",
+						"port_range": "9090",
+					}),
+				),
+			},
+			{
+				// relabelling a rule replaces that rule only
+				Config: CivoFirewallConfigLabels(firewallName, "allow-prometheus", "allow-node-exporter"),
+				Check: resource.ComposeTestCheckFunc(
+					CivoFirewallHasRuleLabels(resName, "allow-prometheus", "allow-node-exporter"),
+					resource.TestCheckResourceAttr(resName, "ingress_rule.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_preventDestroyIfAttached(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigPreventDestroy(firewallName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "prevent_destroy_if_attached", "true"),
+					resource.TestCheckResourceAttr(resName, "instance_ids.#", "1"),
+				),
+			},
+			{
+				// the instance still uses the firewall, so removing it must fail
+				Config:      CivoFirewallConfigPreventDestroy(firewallName, false),
+				ExpectError: regexp.MustCompile("can't be deleted while it's used by instances"),
+			},
+		},
+	})
+}
+
+func TestAccCivoFirewall_attachedResources(t *testing.T) {
+	resName := "civo_firewall.foobar"
+	var firewallName = acctest.RandomWithPrefix("tf-fw")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoFirewallDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoFirewallConfigAttached(firewallName),
+			},
+			{
+				// the firewall is read again once the instance uses it
+				Config: CivoFirewallConfigAttached(firewallName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "instance_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resName, "instance_ids.0", "civo_instance.foobar", "id"),
+					resource.TestCheckResourceAttr(resName, "cluster_ids.#", "0"),
+					resource.TestCheckResourceAttr(resName, "loadbalancer_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func CivoFirewallValues(firewall *civogo.Firewall, name string) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		if firewall.Name != name {
@@ -172,6 +433,42 @@ func CivoFirewallDestroy(s *terraform.State) error {
 	return nil
 }
 
+// CivoFirewallRuleIDs records the ID of each rule of the firewall by label
+func CivoFirewallRuleIDs(n string, ruleIDs map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+		rules, err := client.ListFirewallRules(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			ruleIDs[rule.Label] = rule.ID
+		}
+
+		return nil
+	}
+}
+
+// CivoFirewallRuleKept checks the rule with the label still has the ID recorded by CivoFirewallRuleIDs
+func CivoFirewallRuleKept(n string, ruleIDs map[string]string, label string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		current := map[string]string{}
+		if err := CivoFirewallRuleIDs(n, current)(s); err != nil {
+			return err
+		}
+		if current[label] != ruleIDs[label] {
+			return fmt.Errorf("expected the %s rule to be kept as %s, got %s", label, ruleIDs[label], current[label])
+		}
+
+		return nil
+	}
+}
+
 func CivoFirewallConfigBasic(name string) string {
 	return fmt.Sprintf(`
 resource "civo_firewall" "foobar" {
@@ -214,3 +511,197 @@ resource "civo_firewall" "foobar" {
 	region = "LOCAL"
 }`, name)
 }
+
+func CivoFirewallConfigRuleOrder(name string, reversed bool) string {
+	rules := []string{`
+	ingress_rule {
+		label = "http"
+		port_range = "80"
+		cidr = ["192.168.1.1/32", "192.168.10.4/32"]
+		action = "allow"
+	}`, `
+	ingress_rule {
+		label = "https"
+		port_range = "443"
+		cidr = ["0.0.0.0/0"]
+		action = "allow"
+	}`}
+
+	if reversed {
+		rules[0], rules[1] = rules[1], strings.Replace(rules[0], `["192.168.1.1/32", "192.168.10.4/32"]`, `["192.168.10.4/32", "192.168.1.1/32"]`, 1)
+	}
+
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+%s
+}`, name, strings.Join(rules, "\n"))
+}
+
+func CivoFirewallConfigPortRanges(name, portRanges string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+
+	ingress_rule {
+		label = "web"
+		port_ranges = %s
+		cidr = ["192.168.1.0/24", "10.0.0.0/8"]
+		action = "allow"
+	}
+}`, name, portRanges)
+}
+
+func CivoFirewallConfigICMP(name, ports string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+
+	ingress_rule {
+		label = "ping"
+		protocol = "icmp"
+		%s
+		cidr = ["0.0.0.0/0"]
+		action = "allow"
+	}
+}`, name, ports)
+}
+
+func CivoFirewallConfigRule(name, portRange, cidr string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+
+	ingress_rule {
+		label = "web"
+		port_range = "%s"
+		cidr = ["%s"]
+		action = "allow"
+	}
+}`, name, portRange, cidr)
+}
+
+func CivoFirewallConfigEmpty(name string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+}`, name)
+}
+
+// CivoFirewallHasRuleLabels checks the rules of the firewall have the labels in the API
+func CivoFirewallHasRuleLabels(n string, labels ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+		rules, err := client.ListFirewallRules(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, label := range labels {
+			found := false
+			for _, rule := range rules {
+				if rule.Label == label {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("no rule labelled %q in the firewall %s", label, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func CivoFirewallConfigLabels(name, prometheusLabel, nodeExporterLabel string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	create_default_rules = false
+	region = "LOCAL"
+
+	ingress_rule {
+		label = "%s"
+		port_range = "9090"
+		cidr = ["192.168.1.0/24"]
+		action = "allow"
+	}
+
+	ingress_rule {
+		label = "%s"
+		port_range = "9100"
+		cidr = ["192.168.1.0/24"]
+		action = "allow"
+	}
+}`, name, prometheusLabel, nodeExporterLabel)
+}
+
+func CivoFirewallConfigAttached(name string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_firewall" "foobar" {
+	name = "%s"
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	firewall_id = civo_firewall.foobar.id
+}`, name, name)
+}
+
+func CivoFirewallConfigPreventDestroy(name string, withFirewall bool) string {
+	firewall := fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%s"
+	prevent_destroy_if_attached = true
+}`, name)
+	firewallID := "civo_firewall.foobar.id"
+
+	if !withFirewall {
+		firewall = fmt.Sprintf(`
+data "civo_firewall" "foobar" {
+	name = "%s"
+}`, name)
+		firewallID = "data.civo_firewall.foobar.id"
+	}
+
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+%s
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	firewall_id = %s
+}`, firewall, name, firewallID)
+}
@@ -0,0 +1,99 @@
+package firewall
+
+import (
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ruleIDs returns the IDs of the rules stored for the resource, only its ID for a rule created or
+// imported before rule_ids was tracked
+func ruleIDs(d *schema.ResourceData) []string {
+	ids := []string{d.Id()}
+	for _, v := range d.Get("rule_ids").([]interface{}) {
+		if id := v.(string); id != d.Id() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// findRuleParts returns the rules with the IDs, in the order of the IDs, the ones no longer in
+// the firewall are left out
+func findRuleParts(rules []civogo.FirewallRule, ids []string) []civogo.FirewallRule {
+	found := []civogo.FirewallRule{}
+	for _, id := range ids {
+		for _, rule := range rules {
+			if rule.ID == id {
+				found = append(found, rule)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// createdRuleIDs returns the IDs of the rules created for the config, the one returned by the API
+// first. When the API stores the CIDRs of a rule as separate rules, the others are the new rules
+// doing the same for some of its CIDRs
+func createdRuleIDs(ruleID string, config *civogo.FirewallRuleConfig, before, after []civogo.FirewallRule) []string {
+	existing := map[string]bool{}
+	for _, rule := range before {
+		existing[rule.ID] = true
+	}
+
+	requested := map[string]bool{}
+	for _, cidr := range config.Cidr {
+		requested[cidr] = true
+	}
+
+	ids := []string{ruleID}
+	for _, rule := range after {
+		if rule.ID == ruleID || existing[rule.ID] || len(rule.Cidr) == 0 {
+			continue
+		}
+		if !sameRuleConfig(rule, config) {
+			continue
+		}
+
+		partOf := true
+		for _, cidr := range rule.Cidr {
+			partOf = partOf && requested[cidr]
+		}
+		if partOf {
+			ids = append(ids, rule.ID)
+		}
+	}
+
+	return ids
+}
+
+// importedRuleIDs returns the IDs of the rules to import as the rule, the rule first. The other rules
+// of the firewall with the same label, direction, protocol, ports and action are taken as the ones the
+// API stored for its other CIDRs. A rule without a label can't be told apart, so it's imported alone
+func importedRuleIDs(rule civogo.FirewallRule, rules []civogo.FirewallRule) []string {
+	ids := []string{rule.ID}
+	if rule.Label == "" {
+		return ids
+	}
+
+	config := &civogo.FirewallRuleConfig{
+		Direction: rule.Direction,
+		Protocol:  rule.Protocol,
+		Action:    rule.Action,
+		Label:     rule.Label,
+		Ports:     rule.Ports,
+	}
+	for _, other := range rules {
+		if other.ID != rule.ID && len(other.Cidr) > 0 && sameRuleConfig(other, config) {
+			ids = append(ids, other.ID)
+		}
+	}
+
+	return ids
+}
+
+// sameRuleConfig returns true when the rule has the settings of the config, its CIDRs aside
+func sameRuleConfig(rule civogo.FirewallRule, config *civogo.FirewallRuleConfig) bool {
+	return rule.Direction == config.Direction && rule.Protocol == config.Protocol && rule.Action == config.Action &&
+		rule.Label == config.Label && normalizePortRange(rule.Ports) == normalizePortRange(config.Ports)
+}
@@ -0,0 +1,165 @@
+package firewall
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/civo/civogo"
+)
+
+// ruleConfig returns the config of a tcp rule allowing the CIDRs
+func ruleConfig(direction, label, ports string, cidrs ...string) *civogo.FirewallRuleConfig {
+	return &civogo.FirewallRuleConfig{
+		Direction: direction,
+		Protocol:  "tcp",
+		Action:    "allow",
+		Label:     label,
+		Ports:     ports,
+		Cidr:      cidrs,
+	}
+}
+
+func TestCreatedRuleIDs(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *civogo.FirewallRuleConfig
+		before []civogo.FirewallRule
+		after  []civogo.FirewallRule
+		ids    []string
+	}{
+		{
+			name:   "one rule",
+			config: ruleConfig("ingress", "web", "80", "0.0.0.0/0"),
+			after:  []civogo.FirewallRule{apiRule("1", "ingress", "web", "80", "0.0.0.0/0")},
+			ids:    []string{"1"},
+		},
+		{
+			name:   "one rule per CIDR",
+			config: ruleConfig("ingress", "web", "80", "10.0.0.1/32", "10.0.0.2/32"),
+			after: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "web", "80", "10.0.0.2/32"),
+			},
+			ids: []string{"1", "2"},
+		},
+		{
+			name:   "rules already in the firewall are left out",
+			config: ruleConfig("ingress", "web", "80", "10.0.0.1/32", "10.0.0.2/32"),
+			before: []civogo.FirewallRule{apiRule("0", "ingress", "web", "80", "10.0.0.2/32")},
+			after: []civogo.FirewallRule{
+				apiRule("0", "ingress", "web", "80", "10.0.0.2/32"),
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "web", "80", "10.0.0.2/32"),
+			},
+			ids: []string{"1", "2"},
+		},
+		{
+			name:   "a new rule with a CIDR that wasn't requested is left out",
+			config: ruleConfig("ingress", "web", "80", "10.0.0.1/32", "10.0.0.2/32"),
+			after: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "web", "80", "10.0.0.3/32"),
+			},
+			ids: []string{"1"},
+		},
+		{
+			name:   "a new rule with other settings is left out",
+			config: ruleConfig("ingress", "web", "80", "10.0.0.1/32", "10.0.0.2/32"),
+			after: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "api", "80", "10.0.0.2/32"),
+				apiRule("3", "egress", "web", "80", "10.0.0.2/32"),
+				apiRule("4", "ingress", "web", "443", "10.0.0.2/32"),
+			},
+			ids: []string{"1"},
+		},
+		{
+			name:   "single port ranges match single ports",
+			config: ruleConfig("ingress", "web", "80", "10.0.0.1/32", "10.0.0.2/32"),
+			after: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80-80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "web", "80-80", "10.0.0.2/32"),
+			},
+			ids: []string{"1", "2"},
+		},
+	}
+
+	for _, c := range cases {
+		if got := createdRuleIDs("1", c.config, c.before, c.after); !reflect.DeepEqual(got, c.ids) {
+			t.Errorf("%s: expected the rules %v, got %v", c.name, c.ids, got)
+		}
+	}
+}
+
+func TestFindRuleParts(t *testing.T) {
+	rules := []civogo.FirewallRule{
+		apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+		apiRule("2", "ingress", "web", "80", "10.0.0.2/32"),
+		apiRule("3", "ingress", "web", "80", "10.0.0.3/32"),
+	}
+
+	cases := []struct {
+		name string
+		ids  []string
+		want []string
+	}{
+		{name: "in the order of the IDs", ids: []string{"3", "1"}, want: []string{"3", "1"}},
+		{name: "rules no longer in the firewall are left out", ids: []string{"1", "4", "2"}, want: []string{"1", "2"}},
+		{name: "no rule left", ids: []string{"4"}, want: []string{}},
+	}
+
+	for _, c := range cases {
+		got := []string{}
+		for _, rule := range findRuleParts(rules, c.ids) {
+			got = append(got, rule.ID)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: expected the rules %v, got %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestImportedRuleIDs(t *testing.T) {
+	cases := []struct {
+		name  string
+		rule  civogo.FirewallRule
+		rules []civogo.FirewallRule
+		ids   []string
+	}{
+		{
+			name: "the rules of the other CIDRs are imported with the rule",
+			rule: apiRule("2", "ingress", "web", "80", "10.0.0.2/32"),
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "web", "80", "10.0.0.2/32"),
+				apiRule("3", "ingress", "web", "80-80", "10.0.0.3/32"),
+			},
+			ids: []string{"2", "1", "3"},
+		},
+		{
+			name: "rules with other settings are left out",
+			rule: apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "web", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "api", "80", "10.0.0.2/32"),
+				apiRule("3", "egress", "web", "80", "10.0.0.3/32"),
+			},
+			ids: []string{"1"},
+		},
+		{
+			name: "a rule without a label is imported alone",
+			rule: apiRule("1", "ingress", "", "80", "10.0.0.1/32"),
+			rules: []civogo.FirewallRule{
+				apiRule("1", "ingress", "", "80", "10.0.0.1/32"),
+				apiRule("2", "ingress", "", "80", "10.0.0.2/32"),
+			},
+			ids: []string{"1"},
+		},
+	}
+
+	for _, c := range cases {
+		if got := importedRuleIDs(c.rule, c.rules); !reflect.DeepEqual(got, c.ids) {
+			t.Errorf("%s: expected the rules %v, got %v", c.name, c.ids, got)
+		}
+	}
+}
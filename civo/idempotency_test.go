@@ -0,0 +1,246 @@
+package civo_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// The idempotency tests apply representative configurations of every resource twice, and fail
+// on any change planned after the first apply, see acceptance.IdempotencyTestCase
+
+func TestAccIdempotency_network(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-idem")
+	publicKey, _, err := acctest.RandSSHKeyPair("civo@idempotency-test")
+	if err != nil {
+		t.Fatalf("Cannot generate test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigNetwork(name, publicKey), nil))
+}
+
+func TestAccIdempotency_dns(t *testing.T) {
+	domain := acctest.RandomWithPrefix("tf-idem") + ".example"
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigDNS(domain), nil))
+}
+
+func TestAccIdempotency_instance(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-idem")
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigInstance(name), acceptance.CivoInstanceDestroy))
+}
+
+func TestAccIdempotency_kubernetes(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-idem")
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigKubernetes(name), nil))
+}
+
+func TestAccIdempotency_objectStore(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-idem")
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigObjectStore(name), nil))
+}
+
+func TestAccIdempotency_database(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-idem")
+
+	resource.Test(t, acceptance.IdempotencyTestCase(t, IdempotencyConfigDatabase(name), nil))
+}
+
+// IdempotencyConfigNetwork covers civo_network, civo_network_subnet, civo_firewall, civo_firewall_rule,
+// civo_reserved_ip, civo_ssh_key and civo_volume
+func IdempotencyConfigNetwork(name, publicKey string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%[1]s"
+}
+
+resource "civo_network_subnet" "foobar" {
+	network_id = civo_network.foobar.id
+	name = "%[1]s"
+}
+
+resource "civo_firewall" "foobar" {
+	name = "%[1]s"
+	network_id = civo_network.foobar.id
+	create_default_rules = false
+
+	ingress_rule {
+		label = "web"
+		protocol = "tcp"
+		port_ranges = ["80", "443"]
+		cidr = ["192.168.1.0/24", "10.0.0.0/8"]
+		action = "allow"
+	}
+
+	ingress_rule {
+		label = "ping"
+		protocol = "icmp"
+		cidr = ["0.0.0.0/0"]
+		action = "allow"
+	}
+
+	egress_rule {
+		label = "all"
+		protocol = "tcp"
+		port_range = "1-65535"
+		cidr = ["0.0.0.0/0"]
+		action = "allow"
+	}
+}
+
+resource "civo_firewall_rule" "ssh" {
+	firewall_id = civo_firewall.foobar.id
+	direction = "ingress"
+	protocol = "tcp"
+	port_range = "22"
+	cidr = ["192.168.1.0/24", "172.16.0.0/12"]
+	label = "ssh"
+}
+
+resource "civo_reserved_ip" "foobar" {
+	name = "%[1]s"
+}
+
+resource "civo_ssh_key" "foobar" {
+	name = "%[1]s"
+	public_key = "%[2]s"
+}
+
+resource "civo_volume" "foobar" {
+	name = "%[1]s"
+	size_gb = 10
+	network_id = civo_network.foobar.id
+}
+`, name, publicKey)
+}
+
+// IdempotencyConfigDNS covers civo_dns_domain_name and civo_dns_domain_record
+func IdempotencyConfigDNS(domain string) string {
+	return fmt.Sprintf(`
+resource "civo_dns_domain_name" "foobar" {
+	name = "%s"
+}
+
+resource "civo_dns_domain_record" "www" {
+	domain_id = civo_dns_domain_name.foobar.id
+	type = "A"
+	name = "www"
+	value = "10.10.10.1"
+	ttl = 600
+}
+
+resource "civo_dns_domain_record" "mail" {
+	domain_id = civo_dns_domain_name.foobar.id
+	type = "MX"
+	name = "@"
+	value = "mail.%s"
+	priority = 10
+	ttl = 3600
+}
+`, domain, domain)
+}
+
+// IdempotencyConfigInstance covers civo_instance, civo_volume_attachment and civo_instance_reserved_ip_assignment
+func IdempotencyConfigInstance(name string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_firewall" "foobar" {
+	name = "%[1]s"
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%[1]s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	firewall_id = civo_firewall.foobar.id
+	notes = "idempotency test"
+	tags = ["idempotency", "test"]
+	script = "#!/bin/bash\necho hello\n"
+}
+
+resource "civo_volume" "foobar" {
+	name = "%[1]s"
+	size_gb = 10
+	network_id = civo_instance.foobar.network_id
+}
+
+resource "civo_volume_attachment" "foobar" {
+	instance_id = civo_instance.foobar.id
+	volume_id = civo_volume.foobar.id
+}
+
+resource "civo_reserved_ip" "foobar" {
+	name = "%[1]s"
+}
+
+resource "civo_instance_reserved_ip_assignment" "foobar" {
+	instance_id = civo_instance.foobar.id
+	reserved_ip_id = civo_reserved_ip.foobar.id
+}
+`, name)
+}
+
+// IdempotencyConfigKubernetes covers civo_kubernetes_cluster and civo_kubernetes_node_pool
+func IdempotencyConfigKubernetes(name string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "foobar" {
+	name = "%[1]s"
+	create_default_rules = true
+}
+
+resource "civo_kubernetes_cluster" "foobar" {
+	name = "%[1]s"
+	firewall_id = civo_firewall.foobar.id
+	pools {
+		node_count = 2
+		size = "g4s.kube.small"
+	}
+}
+
+resource "civo_kubernetes_node_pool" "foobar" {
+	cluster_id = civo_kubernetes_cluster.foobar.id
+	node_count = 1
+	size = "g4s.kube.small"
+}
+`, name)
+}
+
+// IdempotencyConfigObjectStore covers civo_object_store and civo_object_store_credential
+func IdempotencyConfigObjectStore(name string) string {
+	return fmt.Sprintf(`
+resource "civo_object_store_credential" "foobar" {
+	name = "%[1]s"
+}
+
+resource "civo_object_store" "foobar" {
+	name = "%[1]s"
+	max_size_gb = 500
+	access_key_id = civo_object_store_credential.foobar.access_key_id
+}
+`, name)
+}
+
+// IdempotencyConfigDatabase covers civo_database
+func IdempotencyConfigDatabase(name string) string {
+	return fmt.Sprintf(`
+resource "civo_database" "foobar" {
+	name = "%s"
+	size = "g3.db.xsmall"
+	engine = "Postgres"
+	version = "13"
+	nodes = 2
+}
+`, name)
+}
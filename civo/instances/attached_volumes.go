@@ -0,0 +1,88 @@
+package instances
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attachedVolumesSchema is the computed list of the volumes attached to an instance
+func attachedVolumesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The volumes attached to the instance, apart from its boot volume, sorted by ID",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the volume",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the volume",
+				},
+				"mount_point": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The device the volume is attached as in the instance, e.g. /dev/vdb, empty while it's being attached",
+				},
+				"size_gb": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The size of the volume (in GB)",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The status of the volume, e.g. attached or attaching",
+				},
+			},
+		},
+	}
+}
+
+// flattenAttachedVolumes returns the attached_volumes of the instance, with the details of the
+// volumes the instance only gives the IDs of
+func flattenAttachedVolumes(apiClient *civogo.Client, instance *civogo.Instance) ([]interface{}, error) {
+	attached := map[string]bool{}
+	for _, volume := range instance.AttachedVolumes {
+		attached[volume.ID] = true
+	}
+	// the boot volume is the root disk, not an attached volume
+	if instance.SourceType == bootVolumeSourceType {
+		delete(attached, instance.SourceID)
+	}
+	if len(attached) == 0 {
+		return []interface{}{}, nil
+	}
+
+	volumes, err := apiClient.ListVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %s", err)
+	}
+
+	attachedVolumes := []interface{}{}
+	for _, volume := range volumes {
+		if !attached[volume.ID] {
+			continue
+		}
+		attachedVolumes = append(attachedVolumes, map[string]interface{}{
+			"id":          volume.ID,
+			"name":        volume.Name,
+			"mount_point": volume.MountPoint,
+			"size_gb":     volume.SizeGigabytes,
+			"status":      volume.Status,
+		})
+	}
+
+	sort.Slice(attachedVolumes, func(i, j int) bool {
+		return attachedVolumes[i].(map[string]interface{})["id"].(string) < attachedVolumes[j].(map[string]interface{})["id"].(string)
+	})
+
+	return attachedVolumes, nil
+}
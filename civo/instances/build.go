@@ -0,0 +1,77 @@
+package instances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// instanceBuildFailed returns true when the status is the one of an instance that failed to build,
+// e.g. ERROR
+func instanceBuildFailed(status string) bool {
+	status = strings.ToUpper(status)
+	return strings.Contains(status, "ERROR") || strings.Contains(status, "FAIL")
+}
+
+// waitForInstanceBuild waits for the new instance to be ACTIVE. When it fails to build, or is still
+// building at the timeout, the error says what likely went wrong as the API doesn't report it
+func waitForInstanceBuild(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client, config *civogo.InstanceConfig) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"BUILDING"},
+		Target:  []string{"ACTIVE"},
+		Refresh: func() (interface{}, string, error) {
+			metrics.RecordRetry(ctx)
+			resp, err := apiClient.GetInstance(d.Id())
+			if err != nil {
+				return 0, "", err
+			}
+			if instanceBuildFailed(resp.Status) {
+				return resp, resp.Status, fmt.Errorf("the instance failed to build, its status is %s", resp.Status)
+			}
+			return resp, resp.Status, nil
+		},
+		Timeout:        d.Timeout(schema.TimeoutCreate),
+		Delay:          3 * time.Second,
+		MinTimeout:     3 * time.Second,
+		NotFoundChecks: 60,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var timeoutErr *resource.TimeoutError
+	if errors.As(err, &timeoutErr) && timeoutErr.LastState != "" {
+		err = fmt.Errorf("the instance is still %s after %s", timeoutErr.LastState, d.Timeout(schema.TimeoutCreate))
+	}
+
+	return fmt.Errorf("error waiting for instance (%s) to be created: %s%s", d.Id(), err, buildFailureReason(apiClient, config))
+}
+
+// buildFailureReason returns the likely reasons an instance didn't build: the disk image, the GPUs
+// or the capacity of the region. The quota isn't one, the API refuses to create an instance over it
+func buildFailureReason(apiClient *civogo.Client, config *civogo.InstanceConfig) string {
+	if config.TemplateID != "" {
+		image, err := apiClient.GetDiskImage(config.TemplateID)
+		if err != nil {
+			return fmt.Sprintf(". The disk image %s isn't available in the region %s, see the civo_disk_image data source", config.TemplateID, apiClient.Region)
+		}
+		if image.State != "" && !strings.EqualFold(image.State, "available") {
+			return fmt.Sprintf(". The disk image %s is %s in the region %s, use an available one", image.Name, image.State, apiClient.Region)
+		}
+	}
+
+	if hint := gpuCreateErrorHint(apiClient, config.Size); hint != "" {
+		return hint
+	}
+
+	return fmt.Sprintf(". The region %s may not have the capacity for the size %s, try again later or use another size", apiClient.Region, config.Size)
+}
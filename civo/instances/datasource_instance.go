@@ -2,10 +2,12 @@ package instances
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -32,7 +34,7 @@ func DataSourceInstance() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.NoZeroValues,
 				ExactlyOneOf: []string{"id", "hostname"},
-				Description:  "The hostname of the Instance",
+				Description:  "The hostname of the Instance, it must match exactly a single instance of the region",
 			},
 			"region": {
 				Type:         schema.TypeString,
@@ -66,11 +68,26 @@ func DataSourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "The size of the disk",
 			},
+			"gpu_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total GPUs of the instance",
+			},
+			"gpu_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The model of the GPUs of the instance",
+			},
 			"network_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "his will be the ID of the network",
 			},
+			"disk_image": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the disk image the instance was built from, empty for an instance booted from a volume",
+			},
 			"template": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -86,6 +103,7 @@ func DataSourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "The notes of the instance",
 			},
+			"attached_volumes": attachedVolumesSchema(),
 			"sshkey_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -110,6 +128,7 @@ func DataSourceInstance() *schema.Resource {
 			"initial_password": {
 				Type:        schema.TypeString,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "Instance initial password",
 			},
 			"private_ip": {
@@ -122,6 +141,12 @@ func DataSourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "The public IP",
 			},
+			"ipv6_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IPv6 address, only set when the network is dual-stack",
+			},
+			"networks": networksSchema(),
 			"pseudo_ip": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -161,7 +186,7 @@ func dataSourceInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 		foundImage = image
 	} else if hostname, ok := d.GetOk("hostname"); ok {
 		log.Printf("[INFO] Getting the instance by hostname")
-		image, err := apiClient.FindInstance(hostname.(string))
+		image, err := findInstanceByHostname(apiClient, hostname.(string))
 		if err != nil {
 			return diag.Errorf("[ERR] failed to retrieve instance: %s", err)
 		}
@@ -179,12 +204,22 @@ func dataSourceInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 	d.Set("cpu_cores", foundImage.CPUCores)
 	d.Set("ram_mb", foundImage.RAMMegabytes)
 	d.Set("disk_gb", foundImage.DiskGigabytes)
+	d.Set("gpu_count", foundImage.GPUCount)
+	d.Set("gpu_type", foundImage.GPUType)
 	d.Set("initial_user", foundImage.InitialUser)
+	redact.Register(foundImage.InitialPassword)
 	d.Set("initial_password", foundImage.InitialPassword)
 	d.Set("sshkey_id", foundImage.SSHKey)
+	d.Set("network_id", foundImage.NetworkID)
+	d.Set("firewall_id", foundImage.FirewallID)
+	d.Set("template", foundImage.TemplateID)
 	d.Set("tags", foundImage.Tags)
 	d.Set("private_ip", foundImage.PrivateIP)
 	d.Set("public_ip", foundImage.PublicIP)
+	d.Set("ipv6_address", foundImage.IPv6)
+	if err := d.Set("networks", flattenInstanceNetworks(foundImage)); err != nil {
+		return diag.Errorf("[ERR] error setting networks: %s", err)
+	}
 	d.Set("pseudo_ip", foundImage.PseudoIP)
 	d.Set("status", foundImage.Status)
 	d.Set("region", apiClient.Region)
@@ -192,5 +227,49 @@ func dataSourceInstanceRead(_ context.Context, d *schema.ResourceData, m interfa
 	d.Set("created_at", foundImage.CreatedAt.UTC().String())
 	d.Set("notes", foundImage.Notes)
 
+	// an instance booted from a volume has no disk image, and the disk image may have been removed since
+	if foundImage.SourceType != bootVolumeSourceType && foundImage.SourceID != "" {
+		if diskImg, err := apiClient.GetDiskImageByName(foundImage.SourceID); err == nil {
+			d.Set("disk_image", diskImg.ID)
+		} else {
+			log.Printf("[WARN] failed to get the disk image %s of the instance %s: %s", foundImage.SourceID, foundImage.ID, err)
+		}
+	}
+
+	attachedVolumes, err := flattenAttachedVolumes(apiClient, foundImage)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrieve the volumes attached to the instance: %s", err)
+	}
+	d.Set("attached_volumes", attachedVolumes)
+
 	return nil
 }
+
+// findInstanceByHostname returns the instance with the hostname. Unlike FindInstance it doesn't fall
+// back to partial matches, which would return web-1 when looking for web
+func findInstanceByHostname(apiClient *civogo.Client, hostname string) (*civogo.Instance, error) {
+	instances, err := apiClient.ListAllInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []civogo.Instance{}
+	for _, instance := range instances {
+		if instance.Hostname == hostname {
+			matches = append(matches, instance)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("there is no instance with the hostname %s in the region %s", hostname, apiClient.Region)
+	case 1:
+		return &matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, instance := range matches {
+		ids[i] = instance.ID
+	}
+	return nil, fmt.Errorf("there are %d instances with the hostname %s in the region %s (%s), use the id instead", len(matches), hostname, apiClient.Region, strings.Join(ids, ", "))
+}
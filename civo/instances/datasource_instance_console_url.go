@@ -0,0 +1,63 @@
+package instances
+
+import (
+	"context"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceInstanceConsoleURL Data source to get from the api the web console URL of an instance
+func DataSourceInstanceConsoleURL() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the URL of the web console of an instance, e.g. to give an operator access to it without the Civo dashboard. The URL is short-lived and gives access to the console to whoever has it, a new one is fetched on each refresh.",
+		ReadContext: dataSourceInstanceConsoleURLRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the instance",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region of the instance",
+			},
+			// computed attributes
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL of the web console of the instance",
+			},
+		},
+	}
+}
+
+func dataSourceInstanceConsoleURLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	log.Printf("[INFO] Getting the console URL of the instance %s", instanceID)
+	url, err := apiClient.GetInstanceConsoleURL(instanceID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrieve the console URL of the instance %s: %s", instanceID, err)
+	}
+	redact.Register(url)
+
+	d.SetId(instanceID)
+	d.Set("url", url)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package instances_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoInstanceConsoleURL_basic(t *testing.T) {
+	datasourceName := "data.civo_instance_console_url.foobar"
+	name := acctest.RandomWithPrefix("instance") + ".com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoInstanceConsoleURLConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "id", "civo_instance.vm", "id"),
+					resource.TestCheckResourceAttrSet(datasourceName, "url"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoInstanceConsoleURLConfig(name string) string {
+	return fmt.Sprintf(`
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "vm" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}
+
+data "civo_instance_console_url" "foobar" {
+	instance_id = civo_instance.vm.id
+}
+`, name)
+}
@@ -23,6 +23,11 @@ func TestAccDataSourceCivoInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(datasourceName, "hostname", name),
 					resource.TestCheckResourceAttrSet(datasourceName, "private_ip"),
 					resource.TestCheckResourceAttrSet(datasourceName, "public_ip"),
+					resource.TestCheckResourceAttrPair(datasourceName, "id", "civo_instance.vm", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "size", "civo_instance.vm", "size"),
+					resource.TestCheckResourceAttrPair(datasourceName, "disk_image", "civo_instance.vm", "disk_image"),
+					resource.TestCheckResourceAttrPair(datasourceName, "network_id", "civo_instance.vm", "network_id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "firewall_id", "civo_instance.vm", "firewall_id"),
 				),
 			},
 		},
@@ -49,6 +54,27 @@ func TestAccDataSourceCivoInstanceByID_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceCivoInstance_attachedVolumes(t *testing.T) {
+	datasourceName := "data.civo_instance.foobar"
+	name := acctest.RandomWithPrefix("instance")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoInstanceConfigAttachedVolumes(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "attached_volumes.#", "1"),
+					resource.TestCheckResourceAttrPair(datasourceName, "attached_volumes.0.id", "civo_volume.data", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "attached_volumes.0.name", "civo_volume.data", "name"),
+					resource.TestCheckResourceAttr(datasourceName, "attached_volumes.0.size_gb", "10"),
+				),
+			},
+		},
+	})
+}
+
 func DataSourceCivoInstanceConfig(name string) string {
 	return fmt.Sprintf(`
 data "civo_instances_size" "small" {
@@ -120,3 +146,36 @@ data "civo_instance" "foobar" {
 }
 `, name)
 }
+
+func DataSourceCivoInstanceConfigAttachedVolumes(name string) string {
+	return fmt.Sprintf(`
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "vm" {
+	hostname = "%s"
+	size = "g3.small"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}
+
+resource "civo_volume" "data" {
+	name = "%s"
+	size_gb = 10
+	network_id = civo_instance.vm.network_id
+}
+
+resource "civo_volume_attachment" "data" {
+	instance_id = civo_instance.vm.id
+	volume_id  = civo_volume.data.id
+}
+
+data "civo_instance" "foobar" {
+	id = civo_volume_attachment.data.instance_id
+}
+`, name, name)
+}
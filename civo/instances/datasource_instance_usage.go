@@ -0,0 +1,104 @@
+package instances
+
+import (
+	"context"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceInstanceUsage Data source to get from the api the resources and the usage stats of an instance
+func DataSourceInstanceUsage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the resources of an instance and the usage stats it reports, e.g. to right-size it. The stats are only reported by instances running the civostatsd agent, they're empty otherwise.",
+		ReadContext: dataSourceInstanceUsageRead,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the instance",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region of the instance",
+			},
+			// computed attributes
+			"size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the size of the instance",
+			},
+			"cpu_cores": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The CPU cores of the instance",
+			},
+			"ram_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The RAM of the instance, in megabytes",
+			},
+			"disk_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The disk of the instance, in gigabytes",
+			},
+			"stats_reported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If true, the instance reports usage stats",
+			},
+			"stats": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The latest usage stats of the instance, as reported by the agent",
+			},
+			"stats_per_minute": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The usage stats of the instance over the last minutes, one per minute, as reported by the agent",
+			},
+			"stats_per_hour": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The usage stats of the instance over the last hours, one per hour, as reported by the agent",
+			},
+		},
+	}
+}
+
+func dataSourceInstanceUsageRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	log.Printf("[INFO] Getting the usage of the instance %s", instanceID)
+	instance, err := apiClient.GetInstance(instanceID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrieve instance %s: %s", instanceID, err)
+	}
+
+	d.SetId(instance.ID)
+	d.Set("size", instance.Size)
+	d.Set("cpu_cores", instance.CPUCores)
+	d.Set("ram_mb", instance.RAMMegabytes)
+	d.Set("disk_gb", instance.DiskGigabytes)
+	d.Set("stats_reported", instance.CivostatsdStats != "" || len(instance.CivostatsdStatsPerMinute) > 0 || len(instance.CivostatsdStatsPerHour) > 0)
+	d.Set("stats", instance.CivostatsdStats)
+	d.Set("stats_per_minute", instance.CivostatsdStatsPerMinute)
+	d.Set("stats_per_hour", instance.CivostatsdStatsPerHour)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package instances_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoInstanceUsage_basic(t *testing.T) {
+	datasourceName := "data.civo_instance_usage.foobar"
+	name := acctest.RandomWithPrefix("instance") + ".com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoInstanceUsageConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "id", "civo_instance.vm", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "size", "civo_instance.vm", "size"),
+					resource.TestCheckResourceAttrPair(datasourceName, "cpu_cores", "civo_instance.vm", "cpu_cores"),
+					resource.TestCheckResourceAttrPair(datasourceName, "ram_mb", "civo_instance.vm", "ram_mb"),
+					resource.TestCheckResourceAttrPair(datasourceName, "disk_gb", "civo_instance.vm", "disk_gb"),
+					resource.TestCheckResourceAttrSet(datasourceName, "stats_reported"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoInstanceUsageConfig(name string) string {
+	return fmt.Sprintf(`
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "vm" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}
+
+data "civo_instance_usage" "foobar" {
+	instance_id = civo_instance.vm.id
+}
+`, name)
+}
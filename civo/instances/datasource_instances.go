@@ -2,10 +2,12 @@ package instances
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/civo/civogo"
 	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,7 +15,7 @@ import (
 func DataSourceInstances() *schema.Resource {
 	dataListConfig := &datalist.ResourceConfig{
 		Description: strings.Join([]string{
-			"Get information on instances for use in other resources, with the ability to filter and sort the results. If no filters are specified, all instances will be returned.",
+			"Get information on instances for use in other resources, with the ability to filter and sort the results. If no filters are specified, all instances of the region will be returned, however many there are.",
 			"Note: You can use the `civo_instance` data source to obtain metadata about a single instance if you already know the id, unique hostname, or unique tag to retrieve.",
 		}, "\n\n"),
 		RecordSchema: instancesSchema(),
@@ -47,18 +49,39 @@ func getDataSourceInstances(m interface{}, extra map[string]interface{}) ([]inte
 	}
 
 	var instance []interface{}
-	partialInstances, err := apiClient.ListInstances(1, 200)
+	partialInstances, err := listInstancePages(apiClient)
 	if err != nil {
 		return nil, fmt.Errorf("[ERR] error retrieving instances: %s", err)
 	}
 
-	for _, partialInstance := range partialInstances.Items {
+	for _, partialInstance := range partialInstances {
 		instance = append(instance, partialInstance)
 	}
 
 	return instance, nil
 }
 
+// instancesPerPage is how many instances are asked for at once when listing them
+const instancesPerPage = 200
+
+// listInstancePages returns the instances of all the pages, so accounts with more instances than
+// fit in a page get all of them filtered and sorted
+func listInstancePages(apiClient *civogo.Client) ([]civogo.Instance, error) {
+	instances := []civogo.Instance{}
+	for page := 1; ; page++ {
+		log.Printf("[INFO] listing the page %d of the instances", page)
+		list, err := apiClient.ListInstances(page, instancesPerPage)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, list.Items...)
+
+		if page >= list.Pages || len(list.Items) == 0 {
+			return instances, nil
+		}
+	}
+}
+
 func flattenDataSourceInstances(instance, _ interface{}, extra map[string]interface{}) (map[string]interface{}, error) {
 
 	region, ok := extra["region"].(string)
@@ -77,17 +100,20 @@ func flattenDataSourceInstances(instance, _ interface{}, extra map[string]interf
 	flattenedInstance["cpu_cores"] = i.CPUCores
 	flattenedInstance["ram_mb"] = i.RAMMegabytes
 	flattenedInstance["disk_gb"] = i.DiskGigabytes
+	flattenedInstance["gpu_count"] = i.GPUCount
+	flattenedInstance["gpu_type"] = i.GPUType
 	flattenedInstance["network_id"] = i.NetworkID
 	flattenedInstance["template"] = i.TemplateID
 	flattenedInstance["initial_user"] = i.InitialUser
 	flattenedInstance["notes"] = i.Notes
 	flattenedInstance["sshkey_id"] = i.SSHKey
 	flattenedInstance["firewall_id"] = i.FirewallID
-	flattenedInstance["tags"] = i.Tags
+	flattenedInstance["tags"] = flattenTags(i.Tags)
 	flattenedInstance["script"] = i.Script
+	redact.Register(i.InitialPassword)
 	flattenedInstance["initial_password"] = i.InitialPassword
-	flattenedInstance["private_ip"] = i.PublicIP
-	flattenedInstance["public_ip"] = i.PrivateIP
+	flattenedInstance["private_ip"] = i.PrivateIP
+	flattenedInstance["public_ip"] = i.PublicIP
 	flattenedInstance["pseudo_ip"] = i.PseudoIP
 	flattenedInstance["status"] = i.Status
 	flattenedInstance["created_at"] = i.CreatedAt.UTC().String()
@@ -129,6 +155,14 @@ func instancesSchema() map[string]*schema.Schema {
 			Type:        schema.TypeInt,
 			Description: "SSD size of the instance",
 		},
+		"gpu_count": {
+			Type:        schema.TypeInt,
+			Description: "GPUs of the instance",
+		},
+		"gpu_type": {
+			Type:        schema.TypeString,
+			Description: "GPU model of the instance",
+		},
 		"network_id": {
 			Type:        schema.TypeString,
 			Description: "Network id of the instance",
@@ -156,7 +190,7 @@ func instancesSchema() map[string]*schema.Schema {
 		"tags": {
 			Type:        schema.TypeSet,
 			Elem:        &schema.Schema{Type: schema.TypeString},
-			Description: "Tags of the instance",
+			Description: "Tags of the instance, filter on several tags with `all = true` to get the instances with all of them",
 		},
 		"script": {
 			Type:        schema.TypeString,
@@ -164,6 +198,7 @@ func instancesSchema() map[string]*schema.Schema {
 		},
 		"initial_password": {
 			Type:        schema.TypeString,
+			Sensitive:   true,
 			Description: "Initial password of the instance",
 		},
 		"private_ip": {
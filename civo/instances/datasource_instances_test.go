@@ -32,6 +32,55 @@ func TestAccDataSourceCivoInstances_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceCivoInstances_tags(t *testing.T) {
+	var instanceHostname = acctest.RandomWithPrefix("tf-test")
+	var instanceHostname2 = acctest.RandomWithPrefix("tf-test")
+	// a tag only the instances of this test have
+	var tag = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoInstancesConfigTags(instanceHostname, instanceHostname2, tag, false),
+			},
+			{
+				Config: DataSourceCivoInstancesConfigTags(instanceHostname, instanceHostname2, tag, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.civo_instances.one_tag", "instances.#", "2"),
+					resource.TestCheckResourceAttr("data.civo_instances.all_tags", "instances.#", "1"),
+					resource.TestCheckResourceAttrPair("data.civo_instances.all_tags", "instances.0.id", "civo_instance.foo", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCivoInstances_filterAndSort(t *testing.T) {
+	var instanceHostname = acctest.RandomWithPrefix("tf-test-a")
+	var instanceHostname2 = acctest.RandomWithPrefix("tf-test-b")
+	var networkLabel = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoInstancesConfigFilterAndSort(instanceHostname, instanceHostname2, networkLabel, false),
+			},
+			{
+				Config: DataSourceCivoInstancesConfigFilterAndSort(instanceHostname, instanceHostname2, networkLabel, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.civo_instances.result", "instances.#", "2"),
+					resource.TestCheckResourceAttr("data.civo_instances.result", "instances.0.hostname", instanceHostname2),
+					resource.TestCheckResourceAttr("data.civo_instances.result", "instances.1.hostname", instanceHostname),
+				),
+			},
+		},
+	})
+}
+
 func DataSourceCivoInstancesConfig(name string, name2 string) string {
 	return fmt.Sprintf(`
 data "civo_instances_size" "small" {
@@ -114,3 +163,97 @@ data "civo_instances" "result" {
 }
 `, name, name2, name)
 }
+
+func DataSourceCivoInstancesConfigTags(name, name2, tag string, withData bool) string {
+	config := fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foo" {
+	hostname = "%[1]s"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	tags = ["%[3]s", "%[3]s-web"]
+}
+
+resource "civo_instance" "bar" {
+	hostname = "%[2]s"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	tags = ["%[3]s"]
+}
+`, name, name2, tag)
+
+	if withData {
+		config += fmt.Sprintf(`
+data "civo_instances" "one_tag" {
+	filter {
+		key = "tags"
+		values = ["%[1]s"]
+	}
+}
+
+data "civo_instances" "all_tags" {
+	filter {
+		key = "tags"
+		values = ["%[1]s", "%[1]s-web"]
+		all = true
+	}
+}
+`, tag)
+	}
+
+	return config
+}
+
+func DataSourceCivoInstancesConfigFilterAndSort(name, name2, networkLabel string, withData bool) string {
+	config := fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_network" "foobar" {
+	label = "%[3]s"
+}
+
+resource "civo_instance" "foo" {
+	hostname = "%[1]s"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	network_id = civo_network.foobar.id
+}
+
+resource "civo_instance" "bar" {
+	hostname = "%[2]s"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	network_id = civo_network.foobar.id
+}
+`, name, name2, networkLabel)
+
+	if withData {
+		config += `
+data "civo_instances" "result" {
+	filter {
+		key = "network_id"
+		values = [civo_network.foobar.id]
+	}
+
+	filter {
+		key = "status"
+		values = ["ACTIVE"]
+	}
+
+	sort {
+		key = "hostname"
+		direction = "desc"
+	}
+}
+`
+	}
+
+	return config
+}
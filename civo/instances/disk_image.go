@@ -0,0 +1,119 @@
+package instances
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// diskImageAvailable is the state of a disk image instances can be built from. A custom image is in
+// other states while it's imported, e.g. importing
+const diskImageAvailable = "available"
+
+// findDiskImage returns the disk image of the region with the ID, the label or the name, public or
+// custom to the account
+func findDiskImage(apiClient *civogo.Client, ref string) (*civogo.DiskImage, error) {
+	images, err := apiClient.ListDiskImages()
+	if err != nil {
+		return nil, err
+	}
+
+	return matchDiskImage(images, ref, apiClient.Region)
+}
+
+// matchDiskImage returns the disk image among the images with the ID, the label or the name. As
+// before labels were supported, a single image whose name or ID only contains the reference is
+// still matched, with a warning
+func matchDiskImage(images []civogo.DiskImage, ref, region string) (*civogo.DiskImage, error) {
+	matches := []civogo.DiskImage{}
+	partialMatches := []civogo.DiskImage{}
+	for _, image := range images {
+		if image.ID == ref {
+			return &image, nil
+		}
+		if strings.EqualFold(image.Label, ref) || image.Name == ref {
+			matches = append(matches, image)
+		} else if partialDiskImageMatch(image, ref) {
+			partialMatches = append(partialMatches, image)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if len(partialMatches) == 1 {
+			log.Printf("[WARN] the disk image %s only matches the name of %s (%s) partially, use its ID, label or full name instead", ref, partialMatches[0].Name, partialMatches[0].ID)
+			return &partialMatches[0], nil
+		}
+		if len(partialMatches) > 1 {
+			return nil, fmt.Errorf("there are %d disk images whose name contains %s in the region %s, use the ID, the label or the full name of one of them", len(partialMatches), ref, region)
+		}
+		return nil, fmt.Errorf("there is no disk image %s in the region %s, see the civo_disk_images data source", ref, region)
+	case 1:
+		return &matches[0], nil
+	}
+
+	return nil, fmt.Errorf("there are %d disk images %s in the region %s, use the ID of one of them", len(matches), ref, region)
+}
+
+// partialDiskImageMatch returns true when the name or the ID of the image contains the reference, as
+// the disk images used to be looked up
+func partialDiskImageMatch(image civogo.DiskImage, ref string) bool {
+	return strings.Contains(image.Name, ref) || strings.Contains(image.ID, ref)
+}
+
+// checkDiskImageAvailable returns an error when instances can't be built from the disk image yet, e.g.
+// a custom image still being imported
+func checkDiskImageAvailable(image *civogo.DiskImage) error {
+	if image.State == "" || strings.EqualFold(image.State, diskImageAvailable) {
+		return nil
+	}
+
+	return fmt.Errorf("the disk image %s (%s) is %s, instances can be built from it once it's %s", image.Name, image.ID, strings.ToLower(image.State), diskImageAvailable)
+}
+
+// checkInstanceDiskImage fails the plan when the disk image doesn't exist in the region of the
+// instance, or isn't available yet. Images that can't be listed are left to the API
+func checkInstanceDiskImage(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("disk_image") || !d.NewValueKnown("disk_image") {
+		return nil
+	}
+
+	ref := d.Get("disk_image").(string)
+	if ref == "" {
+		return nil
+	}
+
+	apiClient, ok := meta.(*civogo.Client)
+	if !ok {
+		return nil
+	}
+	if region := d.Get("region").(string); region != "" {
+		apiClient.Region = region
+	}
+
+	images, err := apiClient.ListDiskImages()
+	if err != nil {
+		log.Printf("[WARN] failed to list the disk images to check the disk image %s: %s", ref, err)
+		return nil
+	}
+
+	image, err := matchDiskImage(images, ref, apiClient.Region)
+	if err != nil {
+		return err
+	}
+
+	return checkDiskImageAvailable(image)
+}
+
+// flattenDiskImage returns the disk_image of the instance built from the image, the configured label
+// or name, full or partial, when it's how the image was referenced, otherwise its ID
+func flattenDiskImage(configured string, image *civogo.DiskImage) string {
+	if configured != "" && (strings.EqualFold(configured, image.Label) || partialDiskImageMatch(*image, configured)) {
+		return configured
+	}
+
+	return image.ID
+}
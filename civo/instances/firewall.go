@@ -0,0 +1,83 @@
+package instances
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// checkInstanceFirewall fails the plan when the firewall of the instance doesn't exist or is in
+// another network than the instance, which the API only rejects once the instance is built
+func checkInstanceFirewall(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("firewall_id") || !d.NewValueKnown("firewall_id") || !d.NewValueKnown("network_id") {
+		return nil
+	}
+
+	firewallID := d.Get("firewall_id").(string)
+	networkID := d.Get("network_id").(string)
+	if firewallID == "" || networkID == "" {
+		return nil
+	}
+
+	apiClient, ok := meta.(*civogo.Client)
+	if !ok {
+		return nil
+	}
+	if region := d.Get("region").(string); region != "" {
+		apiClient.Region = region
+	}
+
+	firewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		log.Printf("[WARN] failed to list the firewalls to check the firewall %s: %s", firewallID, err)
+		return nil
+	}
+
+	for _, firewall := range firewalls {
+		if firewall.ID != firewallID {
+			continue
+		}
+		if firewall.NetworkID != networkID {
+			return fmt.Errorf("the firewall %s (%s) is in the network %s, but the instance is in the network %s, use a firewall of the instance's network", firewall.Name, firewallID, firewall.NetworkID, networkID)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("there is no firewall %s in the region %s", firewallID, apiClient.Region)
+}
+
+// setInstanceFirewall moves the instance to the firewall, without replacing it, and waits for the
+// instance to report it
+func setInstanceFirewall(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client, firewallID string, timeout time.Duration) error {
+	log.Printf("[INFO] setting the firewall of the instance %s to %s", d.Id(), firewallID)
+	if _, err := apiClient.SetInstanceFirewall(d.Id(), firewallID); err != nil {
+		return fmt.Errorf("an error occurred while setting the firewall %s of the instance %s: %s", firewallID, d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"assigning"},
+		Target:  []string{"assigned"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := apiClient.GetInstance(d.Id())
+			if err != nil {
+				return 0, "", err
+			}
+			if resp.FirewallID != firewallID {
+				return resp, "assigning", nil
+			}
+			return resp, "assigned", nil
+		},
+		Timeout:    timeout,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the firewall %s to be set on the instance %s: %s", firewallID, d.Id(), err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package instances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// forceDeleteMaxDelay is the longest wait between two attempts to delete an instance with force_delete
+const forceDeleteMaxDelay = time.Minute
+
+// forceDeleteInstance deletes the instance for force_delete: the deletion is asked again, with a
+// delay doubling up to forceDeleteMaxDelay, until the instance is gone. When it's still there after
+// force_delete_after_minutes, or the delete timeout, it's removed from the state with a warning
+func forceDeleteInstance(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client) diag.Diagnostics {
+	after := time.Duration(d.Get("force_delete_after_minutes").(int)) * time.Minute
+	// leave some time to return before the delete timeout
+	giveUp := time.Now().Add(min(after, d.Timeout(schema.TimeoutDelete)-time.Minute))
+
+	delay := 3 * time.Second
+	for attempt := 1; ; attempt++ {
+		deleted, err := deleteInstanceAttempt(apiClient, d.Id())
+		if deleted {
+			log.Printf("[INFO] instance %s deleted after %d attempts", d.Id(), attempt)
+			return nil
+		}
+		log.Printf("[WARN] the instance %s isn't deleted yet (attempt %d): %s", d.Id(), attempt, err)
+
+		if time.Now().Add(delay).After(giveUp) {
+			return removeUndeletedInstance(d, after, err)
+		}
+
+		metrics.RecordRetry(ctx)
+		select {
+		case <-ctx.Done():
+			return removeUndeletedInstance(d, after, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, forceDeleteMaxDelay)
+	}
+}
+
+// removeUndeletedInstance removes the instance from the state, with a warning as it may still exist
+func removeUndeletedInstance(d *schema.ResourceData, after time.Duration, lastErr error) diag.Diagnostics {
+	log.Printf("[WARN] giving up on deleting the instance %s, removing it from state", d.Id())
+	instanceID := d.Id()
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Instance removed from state without being deleted",
+			Detail: fmt.Sprintf("The instance %s couldn't be deleted within %s (%s), so force_delete removed it from the state. "+
+				"It may still exist in your account and be billed, please check it and delete it from the Civo dashboard or CLI.", instanceID, after, lastErr),
+		},
+	}
+}
+
+// deleteInstanceAttempt asks for the deletion of the instance unless it's already being deleted,
+// and reports whether it's gone. The error tells why it isn't
+func deleteInstanceAttempt(apiClient *civogo.Client, id string) (bool, error) {
+	instance, err := apiClient.GetInstance(id)
+	if err != nil {
+		if errors.Is(err, civogo.DatabaseInstanceNotFoundError) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to retrieve the instance: %s", err)
+	}
+
+	if instance.Status == "DELETING" {
+		return false, fmt.Errorf("the instance is still being deleted")
+	}
+
+	if _, err := apiClient.DeleteInstance(id); err != nil {
+		if errors.Is(err, civogo.DatabaseInstanceNotFoundError) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to delete the instance, its status is %s: %s", instance.Status, err)
+	}
+
+	return false, fmt.Errorf("the deletion was requested, the status was %s", instance.Status)
+}
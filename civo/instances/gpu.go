@@ -0,0 +1,95 @@
+package instances
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findSize returns the size with the name, or nil when there is none
+func findSize(apiClient *civogo.Client, name string) (*civogo.InstanceSize, error) {
+	sizes, err := apiClient.ListInstanceSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, size := range sizes {
+		if size.Name == name {
+			return &size, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findRegion returns the region with the code, or nil when there is none
+func findRegion(apiClient *civogo.Client, code string) (*civogo.Region, error) {
+	regions, err := apiClient.ListRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, region := range regions {
+		if strings.EqualFold(region.Code, code) {
+			return &region, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkInstanceGPUSize fails the plan when a GPU size can't be used for the instance: a size of
+// GPU Kubernetes nodes, or a region without GPUs. Other sizes, or sizes that can't be listed, are
+// left to the API
+func checkInstanceGPUSize(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("size") || !d.NewValueKnown("size") {
+		return nil
+	}
+
+	apiClient, ok := meta.(*civogo.Client)
+	if !ok {
+		return nil
+	}
+	if region := d.Get("region").(string); region != "" {
+		apiClient.Region = region
+	}
+
+	name := d.Get("size").(string)
+	size, err := findSize(apiClient, name)
+	if err != nil {
+		log.Printf("[WARN] failed to list the sizes to check the size %s: %s", name, err)
+		return nil
+	}
+	if size == nil || size.GPUCount == 0 {
+		return nil
+	}
+
+	if !strings.EqualFold(size.Type, "instance") {
+		return fmt.Errorf("the GPU size %s is a %s size, use one of the GPU instance sizes, e.g. from the civo_size data source filtered on the gpu and type keys", name, strings.ToLower(size.Type))
+	}
+
+	region, err := findRegion(apiClient, apiClient.Region)
+	if err != nil {
+		log.Printf("[WARN] failed to list the regions to check the size %s: %s", name, err)
+		return nil
+	}
+	if region != nil && !region.Features.GPU {
+		return fmt.Errorf("the size %s has %d %s GPU(s), but the region %s doesn't offer GPU instances, see the gpu attribute of the civo_region data source", name, size.GPUCount, size.GPUType, region.Code)
+	}
+
+	return nil
+}
+
+// gpuCreateErrorHint returns what to check when a GPU instance failed to build, which is mostly down
+// to the GPUs available in the region, or an empty string for other sizes
+func gpuCreateErrorHint(apiClient *civogo.Client, name string) string {
+	size, err := findSize(apiClient, name)
+	if err != nil || size == nil || size.GPUCount == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(". GPU instances take longer to build and depend on the %s GPUs available in the region %s, consider raising the create timeout or trying another GPU size", size.GPUType, apiClient.Region)
+}
@@ -0,0 +1,31 @@
+package instances
+
+import (
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planReverseDNSFollowsHostname plans the reverse DNS of a renamed instance to follow its hostname,
+// when it isn't configured and is still the hostname the API set it to. A reverse DNS set outside
+// Terraform, or a hostname that isn't a fully qualified domain name, is left as it is
+func planReverseDNSFollowsHostname(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChange("hostname") || !d.NewValueKnown("hostname") {
+		return nil
+	}
+
+	if !d.GetRawConfig().GetAttr("reverse_dns").IsNull() || d.Get("reserved_ipv4").(string) != "" || d.Get("public_ip_required").(string) == publicIPNone {
+		return nil
+	}
+
+	oldHostname, newHostname := d.GetChange("hostname")
+	oldReverseDNS, _ := d.GetChange("reverse_dns")
+	if oldReverseDNS.(string) != oldHostname.(string) {
+		return nil
+	}
+
+	if _, errs := utils.ValidateFQDN(newHostname, "hostname"); len(errs) > 0 {
+		return nil
+	}
+
+	return d.SetNew("reverse_dns", newHostname)
+}
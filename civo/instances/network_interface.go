@@ -0,0 +1,102 @@
+package instances
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkInterfaceSchema is the subnets of other private networks the instance is attached to, on
+// top of its network
+func networkInterfaceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "A subnet of another private network to attach the instance to, on top of its network. Changing them attaches or detaches the subnets without replacing the instance. Subnets attached outside of terraform are detached",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"network_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: utils.ValidateUUID,
+					Description:  "The ID of the network of the subnet",
+				},
+				"subnet_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: utils.ValidateUUID,
+					Description:  "The ID of the subnet, e.g. from a `civo_network_subnet`",
+				},
+			},
+		},
+	}
+}
+
+// expandNetworkInterfaces returns the subnets in the network_interface blocks, by subnet ID
+func expandNetworkInterfaces(set *schema.Set) map[string]civogo.Subnet {
+	subnets := map[string]civogo.Subnet{}
+	for _, v := range set.List() {
+		networkInterface := v.(map[string]interface{})
+		subnetID := networkInterface["subnet_id"].(string)
+		subnets[subnetID] = civogo.Subnet{
+			ID:        subnetID,
+			NetworkID: networkInterface["network_id"].(string),
+		}
+	}
+	return subnets
+}
+
+// flattenNetworkInterfaces returns the network_interface blocks of the subnets the instance is attached to
+func flattenNetworkInterfaces(subnets []civogo.Subnet) []interface{} {
+	networkInterfaces := []interface{}{}
+	for _, subnet := range subnets {
+		networkInterfaces = append(networkInterfaces, map[string]interface{}{
+			"network_id": subnet.NetworkID,
+			"subnet_id":  subnet.ID,
+		})
+	}
+	return networkInterfaces
+}
+
+// networkInterfaceSubnetIDs returns the IDs of the subnets to attach the instance to when it's created
+func networkInterfaceSubnetIDs(d *schema.ResourceData) []string {
+	subnetIDs := []string{}
+	for subnetID := range expandNetworkInterfaces(d.Get("network_interface").(*schema.Set)) {
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	return subnetIDs
+}
+
+// updateNetworkInterfaces detaches the instance from the subnets removed from network_interface, then
+// attaches it to the added ones
+func updateNetworkInterfaces(d *schema.ResourceData, apiClient *civogo.Client) error {
+	o, n := d.GetChange("network_interface")
+	oldSubnets := expandNetworkInterfaces(o.(*schema.Set))
+	newSubnets := expandNetworkInterfaces(n.(*schema.Set))
+
+	for subnetID, subnet := range oldSubnets {
+		if _, ok := newSubnets[subnetID]; ok {
+			continue
+		}
+		log.Printf("[INFO] detaching the subnet %s from the instance %s", subnetID, d.Id())
+		if _, err := apiClient.DetachSubnetFromInstance(subnet.NetworkID, subnetID); err != nil {
+			return fmt.Errorf("an error occurred while detaching the subnet %s from the instance %s: %s", subnetID, d.Id(), err)
+		}
+	}
+
+	for subnetID, subnet := range newSubnets {
+		if _, ok := oldSubnets[subnetID]; ok {
+			continue
+		}
+		log.Printf("[INFO] attaching the subnet %s to the instance %s", subnetID, d.Id())
+		route := &civogo.CreateRoute{ResourceID: d.Id(), ResourceType: "instance"}
+		if _, err := apiClient.AttachSubnetToInstance(subnet.NetworkID, subnetID, route); err != nil {
+			return fmt.Errorf("an error occurred while attaching the subnet %s to the instance %s: %s", subnetID, d.Id(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package instances
+
+import (
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networksSchema is the computed list of the networks an instance is connected to
+func networksSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The networks the instance is connected to, its network first then its subnets",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"network_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the network",
+				},
+				"subnet_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the subnet, empty for the network of the instance",
+				},
+				"subnet_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the subnet, empty for the network of the instance",
+				},
+				"private_ipv4": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The private IPv4 address of the instance in the network, empty for subnets as the API doesn't report it",
+				},
+				"public_ipv4": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The public IPv4 address of the instance, only set for the network of the instance",
+				},
+				"public_ipv6": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The public IPv6 address of the instance, only set for the network of the instance when it's dual-stack",
+				},
+			},
+		},
+	}
+}
+
+// flattenInstanceNetworks returns the networks of the instance, its network then its subnets
+func flattenInstanceNetworks(instance *civogo.Instance) []interface{} {
+	networks := []interface{}{
+		map[string]interface{}{
+			"network_id":   instance.NetworkID,
+			"subnet_id":    "",
+			"subnet_name":  "",
+			"private_ipv4": instance.PrivateIP,
+			"public_ipv4":  instance.PublicIP,
+			"public_ipv6":  instance.IPv6,
+		},
+	}
+
+	for _, subnet := range instance.Subnets {
+		networks = append(networks, map[string]interface{}{
+			"network_id":   subnet.NetworkID,
+			"subnet_id":    subnet.ID,
+			"subnet_name":  subnet.Name,
+			"private_ipv4": "",
+			"public_ipv4":  "",
+			"public_ipv6":  "",
+		})
+	}
+
+	return networks
+}
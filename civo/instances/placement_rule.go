@@ -0,0 +1,123 @@
+package instances
+
+import (
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// placementRuleSchema is where the instance is placed: close to or away from the instances with
+// some tags, or on the hypervisors with some labels
+func placementRuleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: "Where the instance is placed, e.g. away from the other instances of an HA workload. Changing it recreates the instance",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"affinity_rule": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					ForceNew:    true,
+					Description: "A rule placing the instance on the same hypervisors as the instances with the tags, or on other hypervisors",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.StringInSlice([]string{"affinity", "anti-affinity"}, false),
+								Description:  "Either `affinity`, to place the instance with the instances with the tags, or `anti-affinity`, to place it away from them",
+							},
+							"exclusive": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								ForceNew:    true,
+								Default:     false,
+								Description: "If set to true, the instance isn't created when the rule can't be followed. Otherwise the rule is followed when possible (default: false)",
+							},
+							"tags": {
+								Type:        schema.TypeSet,
+								Required:    true,
+								ForceNew:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateTag},
+								Description: "The tags of the instances the rule is about, e.g. the tag the instances of the workload share",
+							},
+						},
+					},
+				},
+				"node_selector": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					ForceNew:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The labels of the hypervisors the instance can be placed on",
+				},
+			},
+		},
+	}
+}
+
+// expandPlacementRule returns the placement rule configured in placement_rule
+func expandPlacementRule(d *schema.ResourceData) civogo.PlacementRule {
+	placementRule := civogo.PlacementRule{}
+
+	rules := d.Get("placement_rule").([]interface{})
+	if len(rules) == 0 || rules[0] == nil {
+		return placementRule
+	}
+	rule := rules[0].(map[string]interface{})
+
+	for _, v := range rule["affinity_rule"].([]interface{}) {
+		affinityRule := v.(map[string]interface{})
+		tags := []string{}
+		for _, tag := range affinityRule["tags"].(*schema.Set).List() {
+			tags = append(tags, tag.(string))
+		}
+		placementRule.AffinityRules = append(placementRule.AffinityRules, civogo.AffinityRule{
+			Type:      affinityRule["type"].(string),
+			Exclusive: affinityRule["exclusive"].(bool),
+			Tags:      tags,
+		})
+	}
+
+	if nodeSelector := rule["node_selector"].(map[string]interface{}); len(nodeSelector) > 0 {
+		placementRule.NodeSelector = map[string]string{}
+		for key, value := range nodeSelector {
+			placementRule.NodeSelector[key] = value.(string)
+		}
+	}
+
+	return placementRule
+}
+
+// flattenPlacementRule returns the placement_rule of the instance, nil when it has none. The API
+// doesn't always return the rule of an instance, in which case the configured one is kept
+func flattenPlacementRule(placementRule civogo.PlacementRule) []interface{} {
+	if len(placementRule.AffinityRules) == 0 && len(placementRule.NodeSelector) == 0 {
+		return nil
+	}
+
+	affinityRules := []interface{}{}
+	for _, affinityRule := range placementRule.AffinityRules {
+		affinityRules = append(affinityRules, map[string]interface{}{
+			"type":      affinityRule.Type,
+			"exclusive": affinityRule.Exclusive,
+			"tags":      flattenTags(affinityRule.Tags),
+		})
+	}
+
+	nodeSelector := map[string]interface{}{}
+	for key, value := range placementRule.NodeSelector {
+		nodeSelector[key] = value
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"affinity_rule": affinityRules,
+			"node_selector": nodeSelector,
+		},
+	}
+}
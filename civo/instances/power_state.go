@@ -0,0 +1,77 @@
+package instances
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The values of desired_state
+const (
+	desiredStateRunning = "running"
+	desiredStateStopped = "stopped"
+)
+
+// instanceStatus returns the status of an instance in the desired state
+func instanceStatus(desiredState string) string {
+	if desiredState == desiredStateStopped {
+		return "SHUTOFF"
+	}
+	return "ACTIVE"
+}
+
+// powerState returns the desired_state matching the status of the instance, or an empty string
+// while it's changing, e.g. BUILDING or REBOOTING
+func powerState(status string) string {
+	switch status {
+	case "ACTIVE":
+		return desiredStateRunning
+	case "SHUTOFF":
+		return desiredStateStopped
+	}
+	return ""
+}
+
+// setInstancePowerState starts or stops the instance and waits for it to reach the desired state
+func setInstancePowerState(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client, desiredState string, timeout time.Duration) error {
+	var err error
+	if desiredState == desiredStateStopped {
+		log.Printf("[INFO] stopping the instance %s", d.Id())
+		_, err = apiClient.StopInstance(d.Id())
+	} else {
+		log.Printf("[INFO] starting the instance %s", d.Id())
+		_, err = apiClient.StartInstance(d.Id())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change the instance %s to %s: %s", d.Id(), desiredState, err)
+	}
+
+	return waitForInstanceStatus(ctx, d, apiClient, instanceStatus(desiredState), timeout)
+}
+
+// waitForInstanceStatus waits for the instance to be in the status, the other status being transitions to it
+func waitForInstanceStatus(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client, status string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"BUILDING", "REBOOTING", "STOPPING", "STARTING", "ACTIVE", "SHUTOFF"},
+		Target:  []string{status},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := apiClient.GetInstance(d.Id())
+			if err != nil {
+				return 0, "", err
+			}
+			return resp, resp.Status, nil
+		},
+		Timeout:    timeout,
+		Delay:      3 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for instance (%s) to be %s: %s", d.Id(), status, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package instances
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// forceNewKeys are the arguments of the instance marked ForceNew in the schema, to keep in sync with it.
+// public_ip_required isn't one of them, a change of it is only saved for the next replacement
+var forceNewKeys = []string{"boot_volume_id", "disk_image", "network_id", "private_ipv4", "region"}
+
+// deletionProtected returns true when the instance is protected from deletion. The value in the
+// state is used, so the protection is lifted by an apply before the instance can be deleted
+func deletionProtected(d *schema.ResourceDiff) bool {
+	protected, _ := d.GetChange("deletion_protection")
+	return protected.(bool)
+}
+
+// checkInstanceReplacement stops the plan when a change of one of the arguments that can't change in
+// place would replace an instance protected from deletion
+func checkInstanceReplacement(d *schema.ResourceDiff) error {
+	if !deletionProtected(d) {
+		return nil
+	}
+
+	if changed := utils.ReplacingChanges(d, forceNewKeys...); len(changed) > 0 {
+		return fmt.Errorf("the instance %s can't be replaced to change %s while deletion_protection is set, set it to false with an apply first",
+			d.Get("hostname"), strings.Join(changed, ", "))
+	}
+
+	return nil
+}
+
+// forceNewInstance forces the replacement of the instance to change the key, unless it's protected
+// from deletion
+func forceNewInstance(d *schema.ResourceDiff, key string) error {
+	if deletionProtected(d) {
+		return fmt.Errorf("the instance %s can't be replaced to change %s while deletion_protection is set, set it to false with an apply first",
+			d.Get("hostname"), key)
+	}
+
+	return d.ForceNew(key)
+}
@@ -0,0 +1,51 @@
+package instances
+
+import (
+	"fmt"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	publicIPCreate = "create"
+	publicIPNone   = "none"
+)
+
+// readPublicIPRequired sets public_ip_required from the instance when it's imported. Otherwise the
+// configured value is kept, as the public IP of an instance without one is its reserved IP, if any
+func readPublicIPRequired(d *schema.ResourceData, instance *civogo.Instance) {
+	if d.Get("public_ip_required").(string) != "" {
+		return
+	}
+
+	if instance.PublicIP != "" && instance.ReservedIP != instance.PublicIP {
+		d.Set("public_ip_required", publicIPCreate)
+	} else {
+		d.Set("public_ip_required", publicIPNone)
+	}
+}
+
+// checkNoPublicIP warns when an instance created with public_ip_required = "none" got a public IP
+// anyway, which happens in regions where instances always get one
+func checkNoPublicIP(d *schema.ResourceData, apiClient *civogo.Client) diag.Diagnostics {
+	if d.Get("public_ip_required").(string) != publicIPNone {
+		return nil
+	}
+
+	instance, err := apiClient.GetInstance(d.Id())
+	if err != nil {
+		return diag.Errorf("[ERR] getting instance: %s", err)
+	}
+
+	if instance.PublicIP == "" || instance.PublicIP == instance.ReservedIP {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Instance created with a public IP",
+		Detail:   fmt.Sprintf("The instance %s was created with public_ip_required = \"none\", but the region %s gave it the public IP %s anyway. The region may not support instances without a public IP, make sure its firewall only allows the traffic it should", d.Get("hostname").(string), apiClient.Region, instance.PublicIP),
+	}}
+}
@@ -0,0 +1,76 @@
+package instances
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findReservedIPv4 finds the reserved IP from reserved_ipv4, which is either its ID, name or address
+func findReservedIPv4(apiClient *civogo.Client, value string) (*civogo.IP, error) {
+	ip, err := apiClient.FindIP(value)
+	if err != nil {
+		if errors.Is(err, civogo.ZeroMatchesError) {
+			return nil, fmt.Errorf("sorry there is no %s IP in your account", value)
+		} else if errors.Is(err, civogo.MultipleMatchesError) {
+			return nil, fmt.Errorf("sorry we found more than one IP with that value in your account")
+		}
+		return nil, fmt.Errorf("error finding IP %s: %s", value, err)
+	}
+	return ip, nil
+}
+
+// readReservedIPv4 empties reserved_ipv4 when the reserved IP was deleted or moved to another
+// resource outside of terraform, so the plan assigns it to the instance again
+func readReservedIPv4(d *schema.ResourceData, apiClient *civogo.Client) error {
+	value := d.Get("reserved_ipv4").(string)
+	if value == "" {
+		return nil
+	}
+
+	ip, err := apiClient.FindIP(value)
+	if err != nil {
+		if errors.Is(err, civogo.ZeroMatchesError) {
+			log.Printf("[WARN] the reserved IP %s of the instance %s doesn't exist anymore", value, d.Id())
+			return d.Set("reserved_ipv4", "")
+		}
+		return fmt.Errorf("error finding IP %s: %s", value, err)
+	}
+
+	if ip.AssignedTo.ID != d.Id() {
+		log.Printf("[WARN] the reserved IP %s isn't assigned to the instance %s anymore (assigned to %q)", value, d.Id(), ip.AssignedTo.ID)
+		return d.Set("reserved_ipv4", "")
+	}
+
+	return nil
+}
+
+// assignReservedIPv4 assigns the reserved IP to the instance, taking it from the resource it's
+// assigned to if any, as the configuration says it belongs to this instance
+func assignReservedIPv4(apiClient *civogo.Client, instanceID, value string) error {
+	ip, err := findReservedIPv4(apiClient, value)
+	if err != nil {
+		return err
+	}
+
+	if ip.AssignedTo.ID == instanceID {
+		return nil
+	}
+
+	if ip.AssignedTo.ID != "" {
+		log.Printf("[WARN] moving the reserved IP %s from the %s %s to the instance %s", value, ip.AssignedTo.Type, ip.AssignedTo.ID, instanceID)
+		if _, err := apiClient.UnassignIP(ip.ID, apiClient.Region); err != nil {
+			return fmt.Errorf("an error occurred while unassigning reserved IP %s from the %s %s: %s", ip.ID, ip.AssignedTo.Type, ip.AssignedTo.ID, err)
+		}
+	}
+
+	if _, err := apiClient.AssignIP(ip.ID, instanceID, "instance", apiClient.Region); err != nil {
+		return fmt.Errorf("an error occurred while assigning reserved IP %s to instance %s: %s", ip.ID, instanceID, err)
+	}
+
+	log.Printf("[INFO] assigned reserved IP %s to the instance %s", value, instanceID)
+	return nil
+}
@@ -0,0 +1,109 @@
+package instances
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sizeFamily returns the family of a size, its name without the last part, e.g. g3 for g3.xsmall
+// or g4g.40 for g4g.40.kube.large. Sizes are only resized within their family
+func sizeFamily(size string) string {
+	if i := strings.LastIndex(size, "."); i > 0 {
+		return size[:i]
+	}
+	return size
+}
+
+// checkInstanceResize forces the replacement of the instance when the new size can't be reached by
+// resizing it: a size of another family, or one with a smaller disk as the disk can't shrink.
+// Otherwise the size is changed in place by resourceInstanceUpdate
+func checkInstanceResize(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("size") || !d.NewValueKnown("size") {
+		return nil
+	}
+
+	o, n := d.GetChange("size")
+	oldSize, newSize := o.(string), n.(string)
+	reason := resizeUnsupportedReason(meta, d.Get("region").(string), oldSize, newSize)
+	if reason == "" {
+		return nil
+	}
+
+	log.Printf("[INFO] the instance %s can't be resized from %s to %s: %s", d.Id(), oldSize, newSize, reason)
+	if err := forceNewInstance(d, "size"); err != nil {
+		return err
+	}
+	utils.LogReplacement(fmt.Sprintf("The instance %s", d.Get("hostname")), []string{"size"}, []string{
+		fmt.Sprintf("the size can't change in place, %s", reason),
+		"its disk, public IP and initial password are lost",
+	})
+
+	return nil
+}
+
+// resizeUnsupportedReason returns why the instance can't be resized from oldSize to newSize, or
+// an empty string when it can. When the sizes can't be listed the resize is left to the API
+func resizeUnsupportedReason(meta interface{}, region, oldSize, newSize string) string {
+	if sizeFamily(oldSize) != sizeFamily(newSize) {
+		return fmt.Sprintf("%s and %s are from different size families", oldSize, newSize)
+	}
+
+	apiClient, ok := meta.(*civogo.Client)
+	if !ok {
+		return ""
+	}
+	if region != "" {
+		apiClient.Region = region
+	}
+
+	sizes, err := apiClient.ListInstanceSizes()
+	if err != nil {
+		log.Printf("[WARN] failed to list the sizes to check the resize from %s to %s: %s", oldSize, newSize, err)
+		return ""
+	}
+
+	disks := map[string]int{}
+	for _, size := range sizes {
+		disks[size.Name] = size.DiskGigabytes
+	}
+	oldDisk, okOld := disks[oldSize]
+	newDisk, okNew := disks[newSize]
+	if okOld && okNew && newDisk < oldDisk {
+		return fmt.Sprintf("the disk of %s (%d GB) is smaller than the one of %s (%d GB)", newSize, newDisk, oldSize, oldDisk)
+	}
+
+	return ""
+}
+
+// waitForInstanceResize waits for the instance to be back in its status with its new size, it can
+// still be in it with the old one right after the resize was asked
+func waitForInstanceResize(ctx context.Context, d *schema.ResourceData, apiClient *civogo.Client, size, status string) error {
+	resizeStateConf := &resource.StateChangeConf{
+		Pending: []string{"BUILDING", "REBOOTING", "RESIZING"},
+		Target:  []string{status},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := apiClient.GetInstance(d.Id())
+			if err != nil {
+				return 0, "", err
+			}
+			if resp.Status == status && resp.Size != size {
+				return resp, "RESIZING", nil
+			}
+			return resp, resp.Status, nil
+		},
+		Timeout:        d.Timeout(schema.TimeoutUpdate),
+		Delay:          3 * time.Second,
+		MinTimeout:     3 * time.Second,
+		NotFoundChecks: 60,
+	}
+	_, err := resizeStateConf.WaitForStateContext(ctx)
+	return err
+}
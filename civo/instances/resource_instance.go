@@ -12,9 +12,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -35,26 +37,29 @@ func ResourceInstance() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				Description:  "A fully qualified domain name that should be set as the instance's hostname",
-				ValidateFunc: utils.ValidateNameSize,
+				Description:  "A fully qualified domain name that should be set as the instance's hostname. It can be changed without replacing the instance. When `reverse_dns` isn't set and still is the hostname, it's changed to the new hostname too",
+				ValidateFunc: utils.ValidateHostname,
 			},
 			"reverse_dns": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "A fully qualified domain name that should be used as the instance's IP's reverse DNS (optional, uses the hostname if unspecified)",
-				ValidateFunc: utils.ValidateName,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"reserved_ipv4"},
+				Description:   "A fully qualified domain name that should be used as the PTR record of the instance's public IP (optional, uses the hostname if unspecified). It can be changed without replacing the instance, and can't be set when using a reserved IP",
+				ValidateFunc:  utils.ValidateFQDN,
 			},
 			"size": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "g3.xsmall",
-				Description: "The name of the size, from the current list, e.g. g3.xsmall",
+				Description: "The name of the size, from the current list, e.g. g3.xsmall. The instance is resized in place to a size of the same family with at least the same disk, other sizes replace it",
 			},
 			"public_ip_required": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "create",
-				Description: "This should be either 'none' or 'create' (default: 'create')",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      publicIPCreate,
+				ValidateFunc: validation.StringInSlice([]string{publicIPCreate, publicIPNone}, false),
+				Description:  "This should be either 'none' or 'create' (default: 'create'). With 'none' the instance only gets a private IP, and a public one if `reserved_ipv4` is set. It only applies when the instance is created, a change is saved without replacing the instance. In regions where instances always get a public IP, the instance is created with one and a warning is shown",
 			},
 			"network_id": {
 				Type:         schema.TypeString,
@@ -66,9 +71,18 @@ func ResourceInstance() *schema.Resource {
 			},
 			"disk_image": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The ID for the disk image to use to build the instance",
+				Optional:     true,
+				Description:  "The ID, the label or the name of the disk image to use to build the instance, a public image or a custom image of the account, available in the region (one of `disk_image` or `boot_volume_id` is required). A part of the name or the ID is still accepted when it matches a single image, but it's deprecated and logs a warning",
 				ForceNew:     true,
+				ExactlyOneOf: []string{"disk_image", "boot_volume_id"},
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"boot_volume_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of a bootable volume (`civo_volume` with `bootable = true`) to use as the root disk of the instance instead of a disk image. The volume must be available and in the network of the instance, and it's kept when the instance is deleted",
+				ForceNew:     true,
+				ExactlyOneOf: []string{"disk_image", "boot_volume_id"},
 				ValidateFunc: utils.ValidateUUID,
 			},
 			"initial_user": {
@@ -80,7 +94,7 @@ func ResourceInstance() *schema.Resource {
 			"notes": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Add some notes to the instance",
+				Description: "Add some notes to the instance, e.g. a runbook link or its owner, shown in the Civo dashboard. Changing them updates the instance in place, and removing them deletes the notes",
 			},
 			"sshkey_id": {
 				Type:         schema.TypeString,
@@ -93,7 +107,7 @@ func ResourceInstance() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ValidateFunc: utils.ValidateUUID,
-				Description:  "The ID of the firewall to use, from the current list. If left blank or not sent, the default firewall will be used (open to all)",
+				Description:  "The ID of the firewall to use, from the current list, in the network of the instance. Changing it moves the instance to the new firewall without replacing it",
 			},
 			"volume_type": {
 				Type:        schema.TypeString,
@@ -103,8 +117,8 @@ func ResourceInstance() *schema.Resource {
 			"tags": {
 				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "An optional list of tags, represented as a key, value pair",
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "An optional list of tags, represented as a key, value pair. Tags can't contain spaces, their order doesn't matter and changing them updates the instance in place",
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateTag},
 			},
 			"script": {
 				Type:     schema.TypeString,
@@ -113,6 +127,33 @@ func ResourceInstance() *schema.Resource {
 					"read/write/executable only by root and then will be executed at the end of the cloud initialization",
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
+			"user_data": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"script", "user_data_base64"},
+				Description: "The user data of the instance, a script run as root at the end of the cloud initialization, as it is or base64 encoded. " +
+					"User data over 16 KiB, or gzipped, is sent compressed. Changing it only replaces the instance with `user_data_replace_on_change`",
+			},
+			"user_data_base64": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsBase64,
+				ConflictsWith: []string{"script", "user_data"},
+				Description:   "The user data of the instance base64 encoded, e.g. from `base64gzip()`, instead of `user_data`",
+			},
+			"user_data_replace_on_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, changing `user_data` or `user_data_base64` replaces the instance to run the new user data. Otherwise the change is only saved (default: false)",
+			},
+			"desired_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      desiredStateRunning,
+				ValidateFunc: validation.StringInSlice([]string{desiredStateRunning, desiredStateStopped}, false),
+				Description:  "The power state of the instance, either `running` or `stopped` (default: `running`). Changing it starts or stops the instance without replacing it",
+			},
 			// Computed resource
 			"cpu_cores": {
 				Type:        schema.TypeInt,
@@ -129,6 +170,16 @@ func ResourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "Instance's disk (GB)",
 			},
+			"gpu_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Instance's GPUs, 0 unless its size is a GPU one",
+			},
+			"gpu_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The model of the instance's GPUs, e.g. A100-80",
+			},
 			"source_type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -162,6 +213,14 @@ func ResourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "Instance's public IP address",
 			},
+			"ipv6_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Instance's IPv6 address, only set when the network is dual-stack",
+			},
+			"networks":          networksSchema(),
+			"network_interface": networkInterfaceSchema(),
+			"placement_rule":    placementRuleSchema(),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -172,6 +231,38 @@ func ResourceInstance() *schema.Resource {
 				Computed:    true,
 				Description: "Timestamp when the instance was created",
 			},
+			"reattach_volumes_on_replace": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the volumes attached to the instance are attached again to the new instance when a change forces its replacement (default: false). This doesn't work together with `create_before_destroy`, as the volumes are still attached to the old instance while the new one is created",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the provider refuses to delete or replace the instance until it's set back to false with an apply (default: false). It's a guard in Terraform only, the Civo API has no protection flag for instances, so the instance can still be deleted from the dashboard or the CLI",
+			},
+			"force_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, a failing deletion of the instance is retried with a growing delay, and the instance is removed from the state with a warning when it still isn't deleted after `force_delete_after_minutes`, rather than failing the whole destroy (default: false). The instance may then be left in your account, to delete by hand",
+			},
+			"force_delete_after_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How long the deletion is retried for when `force_delete` is true, in minutes (the default is 10). It's capped by the delete timeout of the instance",
+			},
+			"attached_volume_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the volumes attached to the instance",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"attached_volumes": attachedVolumesSchema(),
 			"private_ipv4": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -181,7 +272,7 @@ func ResourceInstance() *schema.Resource {
 			"reserved_ipv4": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Can be either the UUID, name, or the IP address of the reserved IP",
+				Description: "Can be either the UUID, name, or the IP address of the reserved IP. It's assigned to the instance at creation, and assigned again when it's moved to another resource or unassigned outside of terraform",
 			},
 		},
 		CreateContext: resourceInstanceCreate,
@@ -192,7 +283,8 @@ func ResourceInstance() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(30 * time.Minute),
+			// GPU instances take longer to build
+			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
@@ -260,11 +352,22 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	if attr, ok := d.GetOk("disk_image"); ok {
-		findDiskImage, err := apiClient.FindDiskImage(attr.(string))
+		diskImage, err := findDiskImage(apiClient, attr.(string))
 		if err != nil {
 			return diag.Errorf("[ERR] failed to get the disk image: %s", err)
 		}
-		config.TemplateID = findDiskImage.ID
+		if err := checkDiskImageAvailable(diskImage); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+		config.TemplateID = diskImage.ID
+	}
+
+	if attr, ok := d.GetOk("boot_volume_id"); ok {
+		if err := checkBootVolume(apiClient, attr.(string), config.NetworkID); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+		config.SourceType = bootVolumeSourceType
+		config.SourceID = attr.(string)
 	}
 
 	if attr, ok := d.GetOk("initial_user"); ok {
@@ -279,13 +382,21 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		config.Script = attr.(string)
 	}
 
-	tfTags := d.Get("tags").(*schema.Set).List()
-	tags := make([]string, len(tfTags))
-	for i, tfTag := range tfTags {
-		tags[i] = tfTag.(string)
+	if payload, ok, err := userDataPayload(d); ok {
+		if err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+		config.Script, err = userDataScript(payload)
+		if err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
 	}
 
-	config.Tags = tags
+	config.Tags = expandTags(d)
+	config.PlacementRule = expandPlacementRule(d)
+	if subnetIDs := networkInterfaceSubnetIDs(d); len(subnetIDs) > 0 {
+		config.Subnets = subnetIDs
+	}
 
 	log.Printf("[INFO] creating the instance %s", d.Get("hostname").(string))
 
@@ -317,30 +428,20 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.SetId(instance.ID)
 
-	createStateConf := &resource.StateChangeConf{
-		Pending: []string{"BUILDING"},
-		Target:  []string{"ACTIVE"},
-		Refresh: func() (interface{}, string, error) {
-			resp, err := apiClient.GetInstance(d.Id())
-			if err != nil {
-				return 0, "", err
-			}
-			return resp, resp.Status, nil
-		},
-		Timeout:        60 * time.Minute,
-		Delay:          3 * time.Second,
-		MinTimeout:     3 * time.Second,
-		NotFoundChecks: 60,
+	if err := waitForInstanceBuild(ctx, d, apiClient, config); err != nil {
+		return diag.FromErr(err)
 	}
-	_, err = createStateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return diag.Errorf("error waiting for instance (%s) to be created: %s", d.Id(), err)
+
+	// the reserved IP is asked at creation, make sure it's assigned before it's read back
+	if attr, ok := d.GetOk("reserved_ipv4"); ok {
+		if err := assignReservedIPv4(apiClient, d.Id(), attr.(string)); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
 	}
 
 	if attr, ok := d.GetOk("firewall_id"); ok {
-		_, errInstance := apiClient.SetInstanceFirewall(d.Id(), attr.(string))
-		if errInstance != nil {
-			return diag.Errorf("[ERR] updating instance firewall: %s", err)
+		if err := setInstanceFirewall(ctx, d, apiClient, attr.(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.Errorf("[ERR] %s", err)
 		}
 	}
 
@@ -352,7 +453,25 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		resp.Notes = attr.(string)
 		_, errInstance := apiClient.UpdateInstance(resp)
 		if errInstance != nil {
-			return diag.Errorf("[ERR] updating instance notes: %s", err)
+			return diag.Errorf("[ERR] updating instance notes: %s", errInstance)
+		}
+	}
+
+	diags = append(diags, checkNoPublicIP(d, apiClient)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if d.Get("reattach_volumes_on_replace").(bool) {
+		diags = append(diags, reattachVolumes(ctx, apiClient, d)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.Get("desired_state").(string) == desiredStateStopped {
+		if err := setInstancePowerState(ctx, d, apiClient, desiredStateStopped, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return append(diags, diag.Errorf("[ERR] %s", err)...)
 		}
 	}
 
@@ -384,58 +503,94 @@ func resourceInstanceRead(_ context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("[ERR] failed to retriving the instance: %s", err)
 	}
 
-	diskImg, err := apiClient.GetDiskImageByName(resp.SourceID)
-	if err != nil {
-		return diag.Errorf("[ERR] failed to get the disk image: %s", err)
+	// an instance booted from a volume has no disk image, its source is the volume
+	if resp.SourceType == bootVolumeSourceType {
+		d.Set("boot_volume_id", resp.SourceID)
+	} else {
+		diskImg, err := findDiskImage(apiClient, resp.SourceID)
+		if err != nil {
+			return diag.Errorf("[ERR] failed to get the disk image: %s", err)
+		}
+		d.Set("disk_image", flattenDiskImage(d.Get("disk_image").(string), diskImg))
 	}
 
+	redact.Register(resp.InitialPassword)
 	if d.Get("write_password").(bool) {
 		d.Set("initial_password", resp.InitialPassword)
 	} else {
 		d.Set("initial_password", "")
 	}
 
-	if resp.Script == "" {
-		d.Set("script", "")
-	}
+	// the script of an instance with user data is made from it, so it's kept as it's configured
+	if _, ok, _ := userDataPayload(d); !ok {
+		decodedScript, err := base64.StdEncoding.DecodeString(resp.Script)
+		if err != nil {
+			return diag.Errorf("[ERR] failed to decode base64 script: %s", err)
+		}
 
-	decodedScript, err := base64.StdEncoding.DecodeString(resp.Script)
-	if err != nil {
-		return diag.Errorf("[ERR] failed to decode base64 script: %s", err)
+		d.Set("script", string(decodedScript))
 	}
-
-	d.Set("script", string(decodedScript))
 	d.Set("hostname", resp.Hostname)
 	d.Set("reverse_dns", resp.ReverseDNS)
 	d.Set("size", resp.Size)
 	d.Set("cpu_cores", resp.CPUCores)
 	d.Set("ram_mb", resp.RAMMegabytes)
 	d.Set("disk_gb", resp.DiskGigabytes)
+	d.Set("gpu_count", resp.GPUCount)
+	d.Set("gpu_type", resp.GPUType)
 	d.Set("initial_user", resp.InitialUser)
 	d.Set("source_type", resp.SourceType)
 	d.Set("source_id", resp.SourceID)
 	d.Set("sshkey_id", resp.SSHKeyID)
-	d.Set("tags", resp.Tags)
+	d.Set("tags", flattenTags(resp.Tags))
 	d.Set("private_ip", resp.PrivateIP)
 	d.Set("public_ip", resp.PublicIP)
+	d.Set("ipv6_address", resp.IPv6)
+	if err := d.Set("networks", flattenInstanceNetworks(resp)); err != nil {
+		return diag.Errorf("[ERR] error setting networks: %s", err)
+	}
+	if err := d.Set("network_interface", flattenNetworkInterfaces(resp.Subnets)); err != nil {
+		return diag.Errorf("[ERR] error setting network_interface: %s", err)
+	}
+	if placementRule := flattenPlacementRule(resp.PlacementRule); placementRule != nil {
+		if err := d.Set("placement_rule", placementRule); err != nil {
+			return diag.Errorf("[ERR] error setting placement_rule: %s", err)
+		}
+	}
 	d.Set("network_id", resp.NetworkID)
+	// a firewall changed outside of terraform, e.g. in the dashboard, is planned back in place
+	if firewallID := d.Get("firewall_id").(string); firewallID != "" && firewallID != resp.FirewallID {
+		log.Printf("[WARN] the firewall of the instance %s changed from %s to %s outside of terraform", d.Id(), firewallID, resp.FirewallID)
+	}
 	d.Set("firewall_id", resp.FirewallID)
 	d.Set("status", resp.Status)
+	if state := powerState(resp.Status); state != "" {
+		d.Set("desired_state", state)
+	}
 	d.Set("created_at", resp.CreatedAt.UTC().String())
 	d.Set("notes", resp.Notes)
-	d.Set("disk_image", diskImg.ID)
 	d.Set("volume_type", resp.VolumeType)
 
-	if resp.PublicIP != "" {
-		d.Set("public_ip_required", "create")
-	} else {
-		d.Set("public_ip_required", "none")
+	attachedVolumeIDs := make([]string, 0, len(resp.AttachedVolumes))
+	for _, volume := range resp.AttachedVolumes {
+		// the boot volume is the root disk, not a volume to attach again on replace
+		if resp.SourceType == bootVolumeSourceType && volume.ID == resp.SourceID {
+			continue
+		}
+		attachedVolumeIDs = append(attachedVolumeIDs, volume.ID)
 	}
+	d.Set("attached_volume_ids", attachedVolumeIDs)
 
-	if d.HasChange("reserved_ipv4") {
-		_, new := d.GetChange("reserved_ipv4")
-		newValue := new.(string)
-		d.Set("reserved_ipv4", newValue)
+	attachedVolumes, err := flattenAttachedVolumes(apiClient, resp)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrieve the volumes attached to the instance: %s", err)
+	}
+	d.Set("attached_volumes", attachedVolumes)
+
+	readPublicIPRequired(d, resp)
+
+	if err := readReservedIPv4(d, apiClient); err != nil {
+		return diag.Errorf("[ERR] failed to check the reserved IP of the instance: %s", err)
 	}
 
 	return nil
@@ -450,41 +605,45 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		apiClient.Region = region.(string)
 	}
 
-	// check if the size change if change we send to resize the instance
+	// start the instance first, so the other changes are made to a running instance
+	if d.HasChange("desired_state") && d.Get("desired_state").(string) == desiredStateRunning {
+		if err := setInstancePowerState(ctx, d, apiClient, desiredStateRunning, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+	}
+
+	// resize the instance in place, sizes it can't be resized to are replacements planned by checkInstanceResize
 	if d.HasChange("size") {
 		newSize := d.Get("size").(string)
 
-		log.Printf("[INFO] resizing the instance %s", d.Id())
-		_, err := apiClient.UpgradeInstance(d.Id(), newSize)
+		// the instance comes back in the status it had before the resize, a stop planned in the same
+		// apply only happens afterwards
+		instance, err := apiClient.GetInstance(d.Id())
+		if err != nil {
+			return diag.Errorf("[ERR] failed to retrieve the instance %s: %s", d.Id(), err)
+		}
+
+		log.Printf("[INFO] resizing the instance %s to %s", d.Id(), newSize)
+		_, err = apiClient.UpgradeInstance(d.Id(), newSize)
 		if err != nil {
-			return diag.Errorf("[WARN] An error occurred while resizing the instance %s", d.Id())
+			return diag.Errorf("[ERR] an error occurred while resizing the instance %s to %s: %s", d.Id(), newSize, err)
 		}
 
-		createStateConf := &resource.StateChangeConf{
-			Pending: []string{"BUILDING", "REBOOTING"},
-			Target:  []string{"ACTIVE"},
-			Refresh: func() (interface{}, string, error) {
-				resp, err := apiClient.GetInstance(d.Id())
-				if err != nil {
-					return 0, "", err
-				}
-				return resp, resp.Status, nil
-			},
-			Timeout:        60 * time.Minute,
-			Delay:          3 * time.Second,
-			MinTimeout:     3 * time.Second,
-			NotFoundChecks: 60,
+		status := instance.Status
+		if powerState(status) == "" {
+			o, _ := d.GetChange("desired_state")
+			status = instanceStatus(o.(string))
 		}
-		_, err = createStateConf.WaitForStateContext(ctx)
-		if err != nil {
-			return diag.Errorf("error waiting for instance (%s) to be created: %s", d.Id(), err)
+		if err := waitForInstanceResize(ctx, d, apiClient, newSize, status); err != nil {
+			return diag.Errorf("error waiting for instance (%s) to be resized: %s", d.Id(), err)
 		}
 	}
 
-	// if notes or hostname have changed, add them to the instance
-	if d.HasChange("notes") || d.HasChange("hostname") {
+	// if notes, hostname or reverse DNS have changed, add them to the instance
+	if d.HasChange("notes") || d.HasChange("hostname") || d.HasChange("reverse_dns") {
 		notes := d.Get("notes").(string)
 		hostname := d.Get("hostname").(string)
+		reverseDNS := d.Get("reverse_dns").(string)
 
 		instance, err := apiClient.GetInstance(d.Id())
 		if err != nil {
@@ -498,73 +657,54 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		if d.HasChange("hostname") {
 			instance.Hostname = hostname
 		}
+		if d.HasChange("reverse_dns") {
+			instance.ReverseDNS = reverseDNS
+		}
 
 		log.Printf("[INFO] updating instance %s", d.Id())
 		_, err = apiClient.UpdateInstance(instance)
 		if err != nil {
-			return diag.Errorf("[ERR] an error occurred while updating notes or hostname of the instance %s", d.Id())
+			return diag.Errorf("[ERR] an error occurred while updating notes, hostname or reverse DNS of the instance %s: %s", d.Id(), err)
 		}
 	}
 
 	// If reserved_ipv4 has changed, update the instance with the new reserved IP
 	if d.HasChange("reserved_ipv4") {
 		oldReservedIP, newReservedIP := d.GetChange("reserved_ipv4")
-		instance, err := apiClient.GetInstance(d.Id())
-		if err != nil {
-			// Check if the instance no longer exists.
-			return diag.Errorf("[ERR] instance %s not found", d.Id())
-		}
 
-		// Unassign the old reserved IP if it exists
-		if oldReservedIP != "" {
-			ip, err := apiClient.FindIP(oldReservedIP.(string))
+		// Unassign the old reserved IP if the instance still has it
+		if oldReservedIP.(string) != "" {
+			ip, err := findReservedIPv4(apiClient, oldReservedIP.(string))
 			if err != nil {
-				if errors.Is(err, civogo.ZeroMatchesError) {
-					return diag.Errorf("sorry there is no %s IP in your account", oldReservedIP)
-				} else if errors.Is(err, civogo.MultipleMatchesError) {
-					return diag.Errorf("sorry we found more than one IP with that value in your account")
-				} else {
-					return diag.Errorf("error finding IP %s: %s", oldReservedIP, err)
-				}
+				return diag.Errorf("[ERR] %s", err)
 			}
 
-			_, err = apiClient.UnassignIP(ip.ID, apiClient.Region)
-			if err != nil {
-				return diag.Errorf("[ERR] an error occurred while unassigning reserved IP %s from instance %s: %s", ip.ID, instance.ID, err)
+			if ip.AssignedTo.ID == d.Id() {
+				_, err = apiClient.UnassignIP(ip.ID, apiClient.Region)
+				if err != nil {
+					return diag.Errorf("[ERR] an error occurred while unassigning reserved IP %s from instance %s: %s", ip.ID, d.Id(), err)
+				}
+				log.Printf("[INFO] unassigned reserved IP %s from the instance %s", oldReservedIP, d.Id())
 			}
-			log.Printf("[INFO] unassigned reserved IP %s from the instance %s", oldReservedIP, d.Id())
 		}
 
-		// Find the new reserved IP
-		ip, err := apiClient.FindIP(newReservedIP.(string))
-		if err != nil {
-			if errors.Is(err, civogo.ZeroMatchesError) {
-				return diag.Errorf("sorry there is no %s IP in your account", newReservedIP)
-			} else if errors.Is(err, civogo.MultipleMatchesError) {
-				return diag.Errorf("sorry we found more than one IP with that value in your account")
-			} else {
-				return diag.Errorf("error finding IP %s: %s", newReservedIP, err)
+		if newReservedIP.(string) != "" {
+			if err := assignReservedIPv4(apiClient, d.Id(), newReservedIP.(string)); err != nil {
+				return diag.Errorf("[ERR] %s", err)
 			}
 		}
-
-		// Assign the new reserved IP to the instance
-		_, err = apiClient.AssignIP(ip.ID, instance.ID, "instance", apiClient.Region)
-		if err != nil {
-			return diag.Errorf("[ERR] an error occurred while assigning reserved IP %s to instance %s: %s", ip.ID, instance.ID, err)
-		}
-
-		log.Printf("[INFO] assigned reserved IP %s to the instance %s", newReservedIP, d.Id())
 	}
 
 	// if a firewall is declared we update the instance
 	if d.HasChange("firewall_id") {
-		firewallID := d.Get("firewall_id").(string)
+		if err := setInstanceFirewall(ctx, d, apiClient, d.Get("firewall_id").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+	}
 
-		log.Printf("[INFO] adding firewall to the instance %s", d.Id())
-		_, err := apiClient.SetInstanceFirewall(d.Id(), firewallID)
-		if err != nil {
-			// check if the instance no longer exists.
-			return diag.Errorf("[ERR] an error occurred while set firewall to the instance %s", d.Id())
+	if d.HasChange("network_interface") {
+		if err := updateNetworkInterfaces(d, apiClient); err != nil {
+			return diag.Errorf("[ERR] %s", err)
 		}
 	}
 
@@ -578,29 +718,48 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	// if tags is declare we update the instance with the tags
 	if d.HasChange("tags") {
-		tfTags := d.Get("tags").(*schema.Set).List()
-		tags := make([]string, len(tfTags))
-		for i, tfTag := range tfTags {
-			tags[i] = tfTag.(string)
-		}
-
 		instance, err := apiClient.GetInstance(d.Id())
 		if err != nil {
 			// check if the instance no longer exists.
 			return diag.Errorf("[ERR] instance %s not found", d.Id())
 		}
 
-		tagsToString := strings.Join(tags, " ")
+		tags := expandTags(d)
 
-		log.Printf("[INFO] adding tags to the instance %s", d.Id())
-		_, err = apiClient.SetInstanceTags(instance, tagsToString)
+		log.Printf("[INFO] setting the tags of the instance %s to %q", d.Id(), tags)
+		_, err = apiClient.SetInstanceTags(instance, strings.Join(tags, " "))
 		if err != nil {
-			return diag.Errorf("[ERR] an error occurred while adding tags to the instance %s", d.Id())
+			return diag.Errorf("[ERR] an error occurred while setting the tags of the instance %s: %s", d.Id(), err)
 		}
 
 	}
 
-	return resourceInstanceRead(ctx, d, m)
+	diags := diag.Diagnostics{}
+	if d.HasChanges("user_data", "user_data_base64") {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The new user data of the instance %s isn't run", d.Id()),
+			Detail:   "The user data only runs when the instance is created. Set user_data_replace_on_change to true to replace the instance when it changes",
+		})
+	}
+	// the public IP is only asked for when the instance is created, and the provider used to read the
+	// value back from the instance, so the state of older instances may not match their configuration
+	if d.HasChange("public_ip_required") {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The public IP of the instance %s isn't changed", d.Id()),
+			Detail:   "public_ip_required only applies when the instance is created, the new value is saved for the next time the instance is replaced",
+		})
+	}
+
+	// stop the instance last, once the other changes are made
+	if d.HasChange("desired_state") && d.Get("desired_state").(string) == desiredStateStopped {
+		if err := setInstancePowerState(ctx, d, apiClient, desiredStateStopped, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+	}
+
+	return append(diags, resourceInstanceRead(ctx, d, m)...)
 }
 
 // function to delete instance.
@@ -612,6 +771,14 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m inter
 		apiClient.Region = region.(string)
 	}
 
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("[ERR] the instance %s can't be deleted while deletion_protection is set, set it to false with an apply first", d.Id())
+	}
+
+	if d.Get("force_delete").(bool) {
+		return forceDeleteInstance(ctx, d, apiClient)
+	}
+
 	log.Printf("[INFO] deleting the instance %s", d.Id())
 	_, err := apiClient.DeleteInstance(d.Id())
 	if err != nil {
@@ -623,6 +790,7 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m inter
 		Pending: []string{"DELETING"},
 		Target:  []string{"DELETED"},
 		Refresh: func() (interface{}, string, error) {
+			metrics.RecordRetry(ctx)
 			resp, err := apiClient.GetInstance(d.Id())
 			if err != nil {
 				if errors.Is(err, civogo.DatabaseInstanceNotFoundError) {
@@ -649,9 +817,156 @@ func customizeDiffInstance(ctx context.Context, d *schema.ResourceDiff, meta int
 	if d.Id() != "" && d.HasChange("script") {
 		return fmt.Errorf("the 'script' field is immutable")
 	}
+
+	if err := checkInstanceReplacement(d); err != nil {
+		return err
+	}
+
+	if err := checkInstanceResize(d, meta); err != nil {
+		return err
+	}
+
+	if err := checkInstanceGPUSize(d, meta); err != nil {
+		return err
+	}
+
+	if err := checkInstanceDiskImage(d, meta); err != nil {
+		return err
+	}
+
+	if err := checkInstanceFirewall(d, meta); err != nil {
+		return err
+	}
+
+	if payload, ok, err := userDataPayload(d); ok && d.NewValueKnown("user_data") && d.NewValueKnown("user_data_base64") {
+		if err != nil {
+			return err
+		}
+		if _, err := userDataScript(payload); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && d.Get("user_data_replace_on_change").(bool) {
+		for _, key := range userDataChanged(d) {
+			if err := forceNewInstance(d, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the PTR record is set on the public IP, so there must be one
+	if reverseDNS, ok := d.GetOk("reverse_dns"); ok && d.HasChange("reverse_dns") && d.Get("public_ip_required").(string) == "none" {
+		return fmt.Errorf("reverse_dns %s can't be set on an instance without a public IP (public_ip_required = \"none\")", reverseDNS)
+	}
+
+	if err := planReverseDNSFollowsHostname(d); err != nil {
+		return err
+	}
+
+	// When the instance is replaced the SDK plans the new one without its prior state,
+	// so the volumes to attach again are carried over from the raw state into the plan
+	if d.Id() == "" && d.Get("reattach_volumes_on_replace").(bool) {
+		if volumeIDs := priorAttachedVolumeIDs(d.GetRawState()); len(volumeIDs) > 0 {
+			log.Printf("[INFO] the replacement instance will get the volumes %s attached again", strings.Join(volumeIDs, ", "))
+			return d.SetNew("attached_volume_ids", volumeIDs)
+		}
+	}
+
 	return nil
 }
 
+// priorAttachedVolumeIDs returns the attached_volume_ids of the instance being replaced, if any
+func priorAttachedVolumeIDs(state cty.Value) []string {
+	if state.IsNull() || !state.IsKnown() || !state.Type().IsObjectType() || !state.Type().HasAttribute("attached_volume_ids") {
+		return nil
+	}
+
+	attr := state.GetAttr("attached_volume_ids")
+	if attr.IsNull() || !attr.IsKnown() || !attr.CanIterateElements() {
+		return nil
+	}
+
+	volumeIDs := []string{}
+	for it := attr.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		if v.IsKnown() && !v.IsNull() && v.Type() == cty.String {
+			volumeIDs = append(volumeIDs, v.AsString())
+		}
+	}
+
+	return volumeIDs
+}
+
+// reattachVolumes attaches the volumes of a replaced instance to the new one. The volumes are
+// released by the old instance while it's deleted, so each one is waited on until it's available
+func reattachVolumes(ctx context.Context, apiClient *civogo.Client, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, v := range d.Get("attached_volume_ids").([]interface{}) {
+		volumeID := v.(string)
+
+		availableStateConf := &retry.StateChangeConf{
+			Pending: []string{"attached", "attaching", "detaching"},
+			Target:  []string{"available"},
+			Refresh: func() (interface{}, string, error) {
+				metrics.RecordRetry(ctx)
+				resp, err := apiClient.GetVolume(volumeID)
+				if err != nil {
+					return nil, "", err
+				}
+				return resp, resp.Status, nil
+			},
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Delay:      3 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		_, err := availableStateConf.WaitForStateContext(ctx)
+		if err != nil {
+			if errors.Is(err, civogo.DatabaseVolumeNotFoundError) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Volume not found",
+					Detail:   fmt.Sprintf("The volume %s was attached to the replaced instance but doesn't exist anymore, so it wasn't attached to the instance %s", volumeID, d.Id()),
+				})
+				continue
+			}
+			return append(diags, diag.Errorf("[ERR] error waiting for volume %s to be released by the replaced instance (this can't work with create_before_destroy): %s", volumeID, err)...)
+		}
+
+		log.Printf("[INFO] attaching the volume %s to the replacement instance %s", volumeID, d.Id())
+		_, err = apiClient.AttachVolume(volumeID, civogo.VolumeAttachConfig{
+			InstanceID: d.Id(),
+			Region:     apiClient.Region,
+		})
+		if err != nil {
+			return append(diags, diag.Errorf("[ERR] error attaching volume %s to instance %s: %s", volumeID, d.Id(), err)...)
+		}
+
+		attachStateConf := &retry.StateChangeConf{
+			Pending: []string{"available", "attaching"},
+			Target:  []string{"attached"},
+			Refresh: func() (interface{}, string, error) {
+				metrics.RecordRetry(ctx)
+				resp, err := apiClient.GetVolume(volumeID)
+				if err != nil {
+					return nil, "", err
+				}
+				return resp, resp.Status, nil
+			},
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Delay:      3 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		_, err = attachStateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return append(diags, diag.Errorf("error waiting for volume %s to be attached: %s", volumeID, err)...)
+		}
+	}
+
+	return diags
+}
+
 // checkNetworkFirstInstance checks if this is the first instance in a given network
 func checkNetworkFirstInstance(apiClient *civogo.Client, networkID string) (bool, error) {
 	// List all instances
@@ -671,3 +986,32 @@ func checkNetworkFirstInstance(apiClient *civogo.Client, networkID string) (bool
 	// Return true if this is the first instance in the network
 	return networkInstanceCount == 0, nil
 }
+
+// bootVolumeSourceType is the source type of the instances booted from a volume
+const bootVolumeSourceType = "volume"
+
+// checkBootVolume returns an error if the volume can't be used as the root disk of an instance in the network
+func checkBootVolume(apiClient *civogo.Client, volumeID, networkID string) error {
+	volume, err := apiClient.GetVolume(volumeID)
+	if err != nil {
+		return fmt.Errorf("failed to get the boot volume %s: %s", volumeID, err)
+	}
+
+	if !volume.Bootable {
+		return fmt.Errorf("the volume %s isn't bootable, create it with bootable = true to boot an instance from it", volume.Name)
+	}
+
+	if volume.InstanceID != "" {
+		return fmt.Errorf("the volume %s is already attached to the instance %s", volume.Name, volume.InstanceID)
+	}
+
+	if volume.Status != "available" {
+		return fmt.Errorf("the volume %s is %s, it must be available to boot an instance from it", volume.Name, volume.Status)
+	}
+
+	if volume.NetworkID != networkID {
+		return fmt.Errorf("the volume %s is in the network %s, it must be in the network of the instance %s", volume.Name, volume.NetworkID, networkID)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package instances_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/civo/civogo"
@@ -44,6 +45,9 @@ func TestAccCivoInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resName, "private_ip"),
 					resource.TestCheckResourceAttrSet(resName, "public_ip"),
 					resource.TestCheckResourceAttrSet(resName, "created_at"),
+					resource.TestCheckResourceAttrPair(resName, "networks.0.network_id", resName, "network_id"),
+					resource.TestCheckResourceAttrPair(resName, "networks.0.private_ipv4", resName, "private_ip"),
+					resource.TestCheckResourceAttrPair(resName, "networks.0.public_ipv4", resName, "public_ip"),
 				),
 			},
 		},
@@ -53,6 +57,7 @@ func TestAccCivoInstance_basic(t *testing.T) {
 // TestAccCivoInstanceSize_update is a test function that verifies the update functionality of the CivoInstanceSize resource.
 func TestAccCivoInstanceSize_update(t *testing.T) {
 	var instance civogo.Instance
+	var instanceID string
 
 	// generate a random name for each test run
 	resName := "civo_instance.foobar"
@@ -68,6 +73,7 @@ func TestAccCivoInstanceSize_update(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					acceptance.CivoInstanceResourceExists(resName, &instance),
 					CivoInstanceValues(&instance, instanceHostname),
+					CivoInstanceSameID(resName, &instanceID),
 					resource.TestCheckResourceAttr(resName, "hostname", instanceHostname),
 					resource.TestCheckResourceAttr(resName, "size", "g3.small"),
 					resource.TestCheckResourceAttr(resName, "initial_user", "civo"),
@@ -86,6 +92,8 @@ func TestAccCivoInstanceSize_update(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					acceptance.CivoInstanceResourceExists(resName, &instance),
 					CivoInstanceUpdated(&instance, instanceHostname),
+					// resized in place, not replaced
+					CivoInstanceSameID(resName, &instanceID),
 					resource.TestCheckResourceAttr(resName, "hostname", instanceHostname),
 					resource.TestCheckResourceAttr(resName, "size", "g3.medium"),
 					resource.TestCheckResourceAttr(resName, "initial_user", "civo"),
@@ -155,6 +163,524 @@ func TestAccCivoInstanceNotes_update(t *testing.T) {
 	})
 }
 
+func TestAccCivoInstanceReverseDNS_update(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigReverseDNS(instanceHostname, "mail.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "reverse_dns", "mail.example.com"),
+				),
+			},
+			{
+				// the PTR record is changed in place, the instance keeps its ID
+				Config: CivoInstanceConfigReverseDNS(instanceHostname, "smtp.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "reverse_dns", "smtp.example.com"),
+					func(_ *terraform.State) error {
+						if instance.ReverseDNS != "smtp.example.com" {
+							return fmt.Errorf("bad reverse DNS, expected \"smtp.example.com\", got: %#v", instance.ReverseDNS)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      CivoInstanceConfigReverseDNS(instanceHostname, "not a domain"),
+				ExpectError: regexp.MustCompile("must be a fully qualified domain name"),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstanceHostname_update(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example.com"
+	var instanceHostnameUpdated = acctest.RandomWithPrefix("tf-test-renamed") + ".example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigDesiredState(instanceHostname, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "hostname", instanceHostname),
+				),
+			},
+			{
+				// the instance is renamed in place, and its reverse DNS follows the hostname
+				Config: CivoInstanceConfigDesiredState(instanceHostnameUpdated, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "hostname", instanceHostnameUpdated),
+					resource.TestCheckResourceAttr(resName, "reverse_dns", instanceHostnameUpdated),
+				),
+			},
+			{
+				Config:      CivoInstanceConfigDesiredState("web_1", "running"),
+				ExpectError: regexp.MustCompile("must be a hostname"),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_bootVolume(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigBootVolume(instanceHostname),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttrPair(resName, "boot_volume_id", "civo_volume.root", "id"),
+					resource.TestCheckResourceAttr(resName, "source_type", "volume"),
+					resource.TestCheckResourceAttr(resName, "disk_image", ""),
+					resource.TestCheckResourceAttr(resName, "attached_volume_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:            resName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"initial_password", "write_password", "reattach_volumes_on_replace", "deletion_protection", "force_delete", "force_delete_after_minutes", "user_data_replace_on_change"},
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_forceDelete(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigForceDelete(instanceHostname),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "force_delete", "true"),
+					resource.TestCheckResourceAttr(resName, "force_delete_after_minutes", "5"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccCivoInstance_deletionProtection is used to test the instance can't be deleted, nor replaced, while it's protected
+func TestAccCivoInstance_deletionProtection(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigDeletionProtection(instanceHostname, "debian-10", true),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      CivoInstanceConfigDeletionProtection(instanceHostname, "ubuntu-jammy", true),
+				ExpectError: regexp.MustCompile("can't be replaced to change disk_image while deletion_protection is set"),
+			},
+			{
+				Config:      CivoInstanceConfigDeletionProtection(instanceHostname, "debian-10", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection is set"),
+			},
+			{
+				Config: CivoInstanceConfigDeletionProtection(instanceHostname, "debian-10", false),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_diskImageName(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoInstanceConfigDiskImageName(instanceHostname, "tf-test-no-such-image"),
+				ExpectError: regexp.MustCompile("there is no disk image tf-test-no-such-image"),
+			},
+			{
+				// the image referenced by its name is kept as it's configured
+				Config: CivoInstanceConfigDiskImageName(instanceHostname, "debian-10"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "disk_image", "debian-10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_networkInterface(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+	var networkLabel = acctest.RandomWithPrefix("tf-test-network")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigNetworkInterface(instanceHostname, networkLabel, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "network_interface.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resName, "network_interface.*.subnet_id", "civo_network_subnet.a", "id"),
+				),
+			},
+			{
+				// the instance is detached from a and attached to b in place
+				Config: CivoInstanceConfigNetworkInterface(instanceHostname, networkLabel, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "network_interface.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resName, "network_interface.*.subnet_id", "civo_network_subnet.b", "id"),
+				),
+			},
+			{
+				// removing the last block detaches b
+				Config: CivoInstanceConfigNetworkInterface(instanceHostname, networkLabel, ""),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "network_interface.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_desiredState(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigDesiredState(instanceHostname, "stopped"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "desired_state", "stopped"),
+					resource.TestCheckResourceAttr(resName, "status", "SHUTOFF"),
+				),
+			},
+			{
+				Config: CivoInstanceConfigDesiredState(instanceHostname, "running"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "desired_state", "running"),
+					resource.TestCheckResourceAttr(resName, "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_firewallChange(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID, firewallA string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigFirewallChange(instanceHostname, "a"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttrPair(resName, "firewall_id", "civo_firewall.a", "id"),
+					func(s *terraform.State) error {
+						firewallA = s.RootModule().Resources["civo_firewall.a"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: CivoInstanceConfigFirewallChange(instanceHostname, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttrPair(resName, "firewall_id", "civo_firewall.b", "id"),
+				),
+			},
+			{
+				// the firewall changed in the dashboard is set back
+				PreConfig: func() {
+					client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+					if _, err := client.SetInstanceFirewall(instanceID, firewallA); err != nil {
+						t.Fatalf("failed to set the firewall of the instance %s: %s", instanceID, err)
+					}
+				},
+				Config: CivoInstanceConfigFirewallChange(instanceHostname, "b"),
+				Check: resource.ComposeTestCheckFunc(
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttrPair(resName, "firewall_id", "civo_firewall.b", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_placementRule(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	var instanceHostname = acctest.RandomWithPrefix("tf-test")
+	var tag = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigPlacementRule(instanceHostname, tag),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists("civo_instance.foobar.0", &instance),
+					acceptance.CivoInstanceResourceExists("civo_instance.foobar.1", &instance),
+					resource.TestCheckResourceAttr("civo_instance.foobar.0", "placement_rule.0.affinity_rule.0.type", "anti-affinity"),
+					resource.TestCheckTypeSetElemAttr("civo_instance.foobar.0", "placement_rule.0.affinity_rule.0.tags.*", tag),
+				),
+			},
+			{
+				Config:   CivoInstanceConfigPlacementRule(instanceHostname, tag),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_notes(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigChangedNotes(instanceHostname, `notes = "owner: platform team"`),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "notes", "owner: platform team"),
+				),
+			},
+			{
+				Config: CivoInstanceConfigChangedNotes(instanceHostname, `notes = "runbook: https://example.com/runbook"`),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "notes", "runbook: https://example.com/runbook"),
+				),
+			},
+			{
+				Config: CivoInstanceConfigChangedNotes(instanceHostname, ""),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttr(resName, "notes", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_noPublicIP(t *testing.T) {
+	var instance civogo.Instance
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigNoPublicIP(instanceHostname),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttr(resName, "public_ip_required", "none"),
+					resource.TestCheckResourceAttr(resName, "public_ip", ""),
+					resource.TestCheckResourceAttrSet(resName, "private_ip"),
+				),
+			},
+			{
+				// an empty public IP isn't drift
+				Config:   CivoInstanceConfigNoPublicIP(instanceHostname),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            resName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"initial_password", "write_password", "reattach_volumes_on_replace", "deletion_protection", "force_delete", "force_delete_after_minutes", "user_data_replace_on_change"},
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_reservedIP(t *testing.T) {
+	var instance civogo.Instance
+	var reservedIPID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigReservedIP(instanceHostname),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID("civo_reserved_ip.www", &reservedIPID),
+					resource.TestCheckResourceAttrPair(resName, "reserved_ipv4", "civo_reserved_ip.www", "ip"),
+					resource.TestCheckResourceAttrPair(resName, "public_ip", "civo_reserved_ip.www", "ip"),
+				),
+			},
+			{
+				// the reserved IP is unassigned outside of terraform, the apply assigns it again
+				PreConfig: func() {
+					client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+					if _, err := client.UnassignIP(reservedIPID, client.Region); err != nil {
+						t.Fatalf("failed to unassign the reserved IP %s: %s", reservedIPID, err)
+					}
+				},
+				Config: CivoInstanceConfigReservedIP(instanceHostname),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					resource.TestCheckResourceAttrPair(resName, "reserved_ipv4", "civo_reserved_ip.www", "ip"),
+					resource.TestCheckResourceAttrPair(resName, "public_ip", "civo_reserved_ip.www", "ip"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoInstance_userData(t *testing.T) {
+	var instance civogo.Instance
+	var instanceID string
+
+	// generate a random name for each test run
+	resName := "civo_instance.foobar"
+	var instanceHostname = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoInstanceConfigUserData(instanceHostname, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+					resource.TestCheckResourceAttrSet(resName, "user_data_base64"),
+					resource.TestCheckResourceAttr(resName, "script", ""),
+				),
+			},
+			{
+				// without user_data_replace_on_change the new user data is only saved
+				Config: CivoInstanceConfigUserData(instanceHostname, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					acceptance.CivoInstanceResourceExists(resName, &instance),
+					CivoInstanceSameID(resName, &instanceID),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCivoInstanceFirewall_update(t *testing.T) {
 	var instance civogo.Instance
 
@@ -225,6 +751,24 @@ func CivoInstanceUpdated(instance *civogo.Instance, name string) resource.TestCh
 	}
 }
 
+// CivoInstanceSameID records the ID of the instance the first time and then checks it didn't change
+func CivoInstanceSameID(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if *id == "" {
+			*id = rs.Primary.ID
+			return nil
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("the instance was replaced, expected the ID %s, got %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
 func CivoInstanceConfigBasic(hostname string) string {
 	return fmt.Sprintf(`
 data "civo_size" "small" {
@@ -282,7 +826,8 @@ data "civo_disk_image" "debian" {
 
 resource "civo_instance" "foobar" {
 	hostname = "%s"
-	size = element(data.civo_instances_size.medium.sizes, 0).name
+	region = "FAKE"
+	size = element(data.civo_size.medium.sizes, 0).name
 	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
 }`, hostname)
 }
@@ -353,3 +898,320 @@ resource "civo_instance" "foobar" {
 	firewall_id = civo_firewall.foobar.id
 }`, hostname)
 }
+
+func CivoInstanceConfigReverseDNS(hostname, reverseDNS string) string {
+	return fmt.Sprintf(`
+data "civo_instances_size" "small" {
+	filter {
+		key = "name"
+		values = ["g3.small"]
+		match_by = "re"
+	}
+
+	filter {
+		key = "type"
+		values = ["instance"]
+	}
+
+}
+
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	reverse_dns = "%s"
+	size = element(data.civo_instances_size.small.sizes, 0).name
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}`, hostname, reverseDNS)
+}
+
+func CivoInstanceConfigBootVolume(hostname string) string {
+	return fmt.Sprintf(`
+data "civo_instances_size" "small" {
+	filter {
+		key = "name"
+		values = ["g3.small"]
+		match_by = "re"
+	}
+
+	filter {
+		key = "type"
+		values = ["instance"]
+	}
+
+}
+
+data "civo_network" "default" {
+	label = "Default"
+}
+
+resource "civo_firewall" "foobar" {
+	name = "fw-boot-volume"
+	network_id = data.civo_network.default.id
+}
+
+resource "civo_volume" "root" {
+	name = "%s-root"
+	size_gb = 20
+	bootable = true
+	network_id = data.civo_network.default.id
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = element(data.civo_instances_size.small.sizes, 0).name
+	network_id = data.civo_network.default.id
+	firewall_id = civo_firewall.foobar.id
+	boot_volume_id = civo_volume.root.id
+}`, hostname, hostname)
+}
+
+func CivoInstanceConfigForceDelete(hostname string) string {
+	return fmt.Sprintf(`
+data "civo_size" "small" {
+	filter {
+		key = "name"
+		values = ["g3.small"]
+		match_by = "re"
+	}
+
+	filter {
+		key = "type"
+		values = ["instance"]
+	}
+}
+
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	region = "FAKE"
+	size = element(data.civo_size.small.sizes, 0).name
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	force_delete = true
+	force_delete_after_minutes = 5
+}`, hostname)
+}
+
+func CivoInstanceConfigDesiredState(hostname, state string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	desired_state = "%s"
+}`, hostname, state)
+}
+
+func CivoInstanceConfigDeletionProtection(hostname, image string, protected bool) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "image" {
+	filter {
+		key = "name"
+		values = ["%s"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.image.diskimages, 0).id
+	deletion_protection = %t
+}`, image, hostname, protected)
+}
+
+func CivoInstanceConfigDiskImageName(hostname, image string) string {
+	return fmt.Sprintf(`
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = "%s"
+}`, hostname, image)
+}
+
+func CivoInstanceConfigNetworkInterface(hostname, networkLabel, subnet string) string {
+	networkInterface := ""
+	if subnet != "" {
+		networkInterface = fmt.Sprintf(`
+	network_interface {
+		network_id = civo_network.backend.id
+		subnet_id = civo_network_subnet.%s.id
+	}`, subnet)
+	}
+
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_network" "backend" {
+	label = "%s"
+}
+
+resource "civo_network_subnet" "a" {
+	network_id = civo_network.backend.id
+	name = "%s-a"
+}
+
+resource "civo_network_subnet" "b" {
+	network_id = civo_network.backend.id
+	name = "%s-b"
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+%s
+}`, networkLabel, networkLabel, networkLabel, hostname, networkInterface)
+}
+
+func CivoInstanceConfigFirewallChange(hostname, firewall string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_network" "foobar" {
+	label = "%[1]s"
+}
+
+resource "civo_firewall" "a" {
+	name = "%[1]s-a"
+	network_id = civo_network.foobar.id
+}
+
+resource "civo_firewall" "b" {
+	name = "%[1]s-b"
+	network_id = civo_network.foobar.id
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%[1]s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	network_id = civo_network.foobar.id
+	firewall_id = civo_firewall.%[2]s.id
+}`, hostname, firewall)
+}
+
+func CivoInstanceConfigPlacementRule(hostname, tag string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	count = 2
+	hostname = "%s-${count.index}"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	tags = ["%[2]s"]
+
+	placement_rule {
+		affinity_rule {
+			type = "anti-affinity"
+			tags = ["%[2]s"]
+		}
+	}
+}`, hostname, tag)
+}
+
+func CivoInstanceConfigChangedNotes(hostname, notes string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	%s
+}`, hostname, notes)
+}
+
+func CivoInstanceConfigNoPublicIP(hostname string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	public_ip_required = "none"
+}`, hostname)
+}
+
+func CivoInstanceConfigReservedIP(hostname string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_reserved_ip" "www" {
+	name = "%s"
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	reserved_ipv4 = civo_reserved_ip.www.ip
+}`, hostname, hostname)
+}
+
+func CivoInstanceConfigUserData(hostname, message string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+	user_data_base64 = base64gzip("#!/bin/bash\necho %s > /root/user-data\n")
+}`, hostname, message)
+}
@@ -0,0 +1,36 @@
+package instances
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// validateTag rejects tags with spaces, as the API saves the tags of an instance space separated
+// and a tag with a space would come back as several tags
+var validateTag = validation.StringMatch(regexp.MustCompile(`^\S+$`), "tags can't be empty or contain spaces")
+
+// expandTags returns the tags of the instance sorted, so they're sent in the same order whatever
+// the order of the configuration
+func expandTags(d *schema.ResourceData) []string {
+	tags := []string{}
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		tags = append(tags, tag.(string))
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// flattenTags returns the tags as a set, the type of the tags of the records of civo_instances,
+// which the filters of the data source expect
+func flattenTags(tags []string) *schema.Set {
+	flattened := []interface{}{}
+	for _, tag := range tags {
+		if tag != "" {
+			flattened = append(flattened, tag)
+		}
+	}
+	return schema.NewSet(schema.HashString, flattened)
+}
@@ -0,0 +1,118 @@
+package instances
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userDataGzipThreshold is the size from which user data is sent compressed
+const userDataGzipThreshold = 16 * 1024
+
+// gzipMagic starts any gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// userDataGetter is what userDataPayload reads the arguments from, a schema.ResourceData or a schema.ResourceDiff
+type userDataGetter interface {
+	GetOk(string) (interface{}, bool)
+}
+
+// userDataPayload returns the user data of the instance, from user_data, as it is or base64 encoded,
+// or from user_data_base64, and whether one of them is set
+func userDataPayload(d userDataGetter) ([]byte, bool, error) {
+	if v, ok := d.GetOk("user_data_base64"); ok {
+		payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(v.(string)))
+		if err != nil {
+			return nil, true, fmt.Errorf("user_data_base64 isn't valid base64: %s", err)
+		}
+		return payload, true, nil
+	}
+
+	if v, ok := d.GetOk("user_data"); ok {
+		userData := v.(string)
+		// a script always has characters base64 doesn't, e.g. the #! of its first line
+		if payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(userData)); err == nil {
+			return payload, true, nil
+		}
+		return []byte(userData), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// userDataScript returns the script sent for the user data. Civo runs the script of an instance at
+// the end of the cloud initialization, so the user data must be a script. Compressed or large user
+// data is sent gzipped in a small script which extracts and runs it
+func userDataScript(payload []byte) (string, error) {
+	compressed := bytes.HasPrefix(payload, gzipMagic)
+
+	script := payload
+	if compressed {
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress the user data: %s", err)
+		}
+		script, err = io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress the user data: %s", err)
+		}
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(script), []byte("#cloud-config")) {
+		return "", fmt.Errorf("the user data is a #cloud-config document, but Civo runs it as a script at the end of the cloud initialization, so it must be a script, e.g. starting with #!/bin/bash")
+	}
+
+	if !compressed && len(payload) < userDataGzipThreshold {
+		return string(payload), nil
+	}
+
+	if !compressed {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(payload); err != nil {
+			return "", fmt.Errorf("failed to compress the user data: %s", err)
+		}
+		if err := writer.Close(); err != nil {
+			return "", fmt.Errorf("failed to compress the user data: %s", err)
+		}
+		payload = buf.Bytes()
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+# user data of the instance, compressed by the Civo terraform provider
+set -e
+script=$(mktemp)
+base64 -d > "$script.gz" <<'CIVO_USER_DATA'
+%s
+CIVO_USER_DATA
+gunzip -f "$script.gz"
+chmod 700 "$script"
+exec "$script"
+`, wrapBase64(base64.StdEncoding.EncodeToString(payload), 76)), nil
+}
+
+// wrapBase64 splits base64 text in lines of the width
+func wrapBase64(encoded string, width int) string {
+	lines := []string{}
+	for len(encoded) > width {
+		lines = append(lines, encoded[:width])
+		encoded = encoded[width:]
+	}
+	return strings.Join(append(lines, encoded), "\n")
+}
+
+// userDataChanged returns the user data arguments changed by the plan
+func userDataChanged(d *schema.ResourceDiff) []string {
+	changed := []string{}
+	for _, key := range []string{"user_data", "user_data_base64"} {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
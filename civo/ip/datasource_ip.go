@@ -0,0 +1,234 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceIP function returns a schema.Resource that represents any IP address of the account,
+// to find out what it's used by, e.g. when working from logs
+func DataSourceIP() *schema.Resource {
+	return &schema.Resource{
+		Description: strings.Join([]string{
+			"Find out what an IP address of your Civo account is: its type, the resource it belongs to, its network and region.",
+			"The public and private IPs of instances, load balancers and databases, the API and node IPs of Kubernetes clusters, and reserved IPs are looked up in the region.",
+			"An error will be raised if no resource of the region uses the IP.",
+		}, "\n\n"),
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The IP address to look up",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region to look the IP up in, if not declared we use the region in declared in the provider",
+			},
+			// Computed resource
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The type of the IP, one of `instance_public`, `instance_private`, `reserved`, `loadbalancer_public`, `loadbalancer_private`, " +
+					"`kubernetes_api`, `kubernetes_node`, `database_public` or `database_private`",
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the resource the IP belongs to, the reserved IP itself when it isn't assigned",
+			},
+			"owner_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the resource the IP belongs to",
+			},
+			"owner_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the resource the IP belongs to, one of `instance`, `loadbalancer`, `kubernetes_cluster`, `database` or `reserved_ip`",
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the network of the resource the IP belongs to",
+			},
+			"reserved_ip_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the reserved IP, when the IP is a reserved one",
+			},
+		},
+		ReadContext: dataSourceIPRead,
+	}
+}
+
+// ipOwner is what an IP address is used by
+type ipOwner struct {
+	ipType       string
+	ownerType    string
+	ownerID      string
+	ownerName    string
+	networkID    string
+	reservedIPID string
+}
+
+func dataSourceIPRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	address := d.Get("address").(string)
+
+	log.Printf("[INFO] looking up the IP %s", address)
+	owner, err := findIPOwner(apiClient, address)
+	if err != nil {
+		return diag.Errorf("[ERR] %s", err)
+	}
+	if owner == nil {
+		return diag.Errorf("[ERR] the IP %s isn't used by any resource in the region %s", address, apiClient.Region)
+	}
+
+	d.SetId(address)
+	d.Set("region", apiClient.Region)
+	d.Set("type", owner.ipType)
+	d.Set("owner_id", owner.ownerID)
+	d.Set("owner_name", owner.ownerName)
+	d.Set("owner_type", owner.ownerType)
+	d.Set("network_id", owner.networkID)
+	d.Set("reserved_ip_id", owner.reservedIPID)
+
+	return nil
+}
+
+// findIPOwner returns what uses the IP among the resources of the current region, or nil
+func findIPOwner(apiClient *civogo.Client, address string) (*ipOwner, error) {
+	reserved, err := findReservedIP(apiClient, address)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := findResourceWithIP(apiClient, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved == nil {
+		return owner, nil
+	}
+
+	// a reserved IP is reported as such, with the resource it's assigned to as its owner
+	if owner == nil {
+		owner = &ipOwner{
+			ownerType: "reserved_ip",
+			ownerID:   reserved.ID,
+			ownerName: reserved.Name,
+		}
+		if reserved.AssignedTo.ID != "" {
+			owner.ownerType = reserved.AssignedTo.Type
+			owner.ownerID = reserved.AssignedTo.ID
+			owner.ownerName = reserved.AssignedTo.Name
+		}
+	}
+	owner.ipType = "reserved"
+	owner.reservedIPID = reserved.ID
+
+	return owner, nil
+}
+
+// findReservedIP returns the reserved IP with the address, or nil
+func findReservedIP(apiClient *civogo.Client, address string) (*civogo.IP, error) {
+	ips, err := apiClient.ListIPs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reserved IPs: %s", err)
+	}
+	for _, ip := range ips.Items {
+		if sameIP(ip.IP, address) {
+			return &ip, nil
+		}
+	}
+	return nil, nil
+}
+
+// findResourceWithIP returns the instance, load balancer, Kubernetes cluster or database using the address, or nil
+func findResourceWithIP(apiClient *civogo.Client, address string) (*ipOwner, error) {
+	instances, err := apiClient.ListAllInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %s", err)
+	}
+	for _, instance := range instances {
+		if ipType := matchIP(address, "instance", instance.PublicIP, instance.PrivateIP); ipType != "" {
+			return &ipOwner{ipType: ipType, ownerType: "instance", ownerID: instance.ID, ownerName: instance.Hostname, networkID: instance.NetworkID}, nil
+		}
+	}
+
+	loadBalancers, err := apiClient.ListLoadBalancers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %s", err)
+	}
+	for _, loadBalancer := range loadBalancers {
+		if ipType := matchIP(address, "loadbalancer", loadBalancer.PublicIP, loadBalancer.PrivateIP); ipType != "" {
+			return &ipOwner{ipType: ipType, ownerType: "loadbalancer", ownerID: loadBalancer.ID, ownerName: loadBalancer.Name, networkID: loadBalancer.NetworkID}, nil
+		}
+	}
+
+	clusters, err := apiClient.ListKubernetesClusters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Kubernetes clusters: %s", err)
+	}
+	for _, cluster := range clusters.Items {
+		owner := &ipOwner{ownerType: "kubernetes_cluster", ownerID: cluster.ID, ownerName: cluster.Name, networkID: cluster.NetworkID}
+		if sameIP(cluster.MasterIP, address) {
+			owner.ipType = "kubernetes_api"
+			return owner, nil
+		}
+		for _, node := range cluster.Instances {
+			if sameIP(node.PublicIP, address) {
+				owner.ipType = "kubernetes_node"
+				return owner, nil
+			}
+		}
+	}
+
+	databases, err := apiClient.ListDatabases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %s", err)
+	}
+	for _, database := range databases.Items {
+		if ipType := matchIP(address, "database", database.PublicIPv4, database.PrivateIPv4); ipType != "" {
+			return &ipOwner{ipType: ipType, ownerType: "database", ownerID: database.ID, ownerName: database.Name, networkID: database.NetworkID}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matchIP returns <prefix>_public or <prefix>_private when the address is the public or the private IP
+func matchIP(address, prefix, publicIP, privateIP string) string {
+	switch {
+	case sameIP(publicIP, address):
+		return prefix + "_public"
+	case sameIP(privateIP, address):
+		return prefix + "_private"
+	}
+	return ""
+}
+
+// sameIP compares two IP addresses, whatever the way they're written
+func sameIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	return ipA != nil && ipB != nil && ipA.Equal(ipB)
+}
@@ -0,0 +1,96 @@
+package ip_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceIP_instance(t *testing.T) {
+	publicName := "data.civo_ip.public"
+	privateName := "data.civo_ip.private"
+	name := acctest.RandomWithPrefix("ip-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceIPConfigInstance(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(publicName, "type", "instance_public"),
+					resource.TestCheckResourceAttr(publicName, "owner_type", "instance"),
+					resource.TestCheckResourceAttrPair(publicName, "owner_id", "civo_instance.foobar", "id"),
+					resource.TestCheckResourceAttrPair(publicName, "network_id", "civo_instance.foobar", "network_id"),
+					resource.TestCheckResourceAttr(publicName, "reserved_ip_id", ""),
+					resource.TestCheckResourceAttr(privateName, "type", "instance_private"),
+					resource.TestCheckResourceAttrPair(privateName, "owner_id", "civo_instance.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceIP_reserved(t *testing.T) {
+	datasourceName := "data.civo_ip.foobar"
+	name := acctest.RandomWithPrefix("ip-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceIPConfigReserved(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "type", "reserved"),
+					resource.TestCheckResourceAttr(datasourceName, "owner_type", "reserved_ip"),
+					resource.TestCheckResourceAttrPair(datasourceName, "owner_id", "civo_reserved_ip.newip", "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "reserved_ip_id", "civo_reserved_ip.newip", "id"),
+					resource.TestCheckResourceAttr(datasourceName, "region", "LON1"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceIPConfigInstance(name string) string {
+	return fmt.Sprintf(`
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "foobar" {
+	hostname = "%s"
+	size = "g3.xsmall"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}
+
+data "civo_ip" "public" {
+	address = civo_instance.foobar.public_ip
+}
+
+data "civo_ip" "private" {
+	address = civo_instance.foobar.private_ip
+}
+`, name)
+}
+
+func DataSourceIPConfigReserved(name string) string {
+	return fmt.Sprintf(`
+resource "civo_reserved_ip" "newip" {
+	name = "%s"
+	region = "LON1"
+}
+
+data "civo_ip" "foobar" {
+	address = civo_reserved_ip.newip.ip
+	region = "LON1"
+}
+`, name)
+}
@@ -0,0 +1,116 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceKubernetesApplications Data source to list the marketplace applications installed on a cluster
+func DataSourceKubernetesApplications() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		Description: "Get the marketplace applications installed on a Kubernetes cluster, with the ability to filter and sort the results. " +
+			"This can be used to detect drift between what's installed on the cluster and the `applications` managed with Terraform.",
+		RecordSchema: kubernetesApplicationSchema(),
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "The ID of the cluster to list the applications of",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The region of the cluster, if not declared we use the region declared in the provider",
+			},
+		},
+		ResultAttributeName: "applications",
+		FlattenRecord:       flattenDataSourceKubernetesApplication,
+		GetRecords:          getDataSourceKubernetesApplications,
+	}
+
+	return datalist.NewResource(dataListConfig)
+}
+
+func getDataSourceKubernetesApplications(m interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	region, ok := extra["region"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to find `region` key from query data")
+	}
+
+	if region != "" {
+		apiClient.Region = region
+	}
+
+	clusterID := extra["cluster_id"].(string)
+
+	cluster, err := apiClient.GetKubernetesCluster(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("[ERR] error retrieving the kubernetes cluster %s: %s", clusterID, err)
+	}
+
+	applications := []interface{}{}
+	for _, application := range cluster.InstalledApplications {
+		applications = append(applications, application)
+	}
+
+	return applications, nil
+}
+
+func flattenDataSourceKubernetesApplication(application, _ interface{}, _ map[string]interface{}) (map[string]interface{}, error) {
+	a := application.(civogo.KubernetesInstalledApplication)
+
+	flattenedApplication := map[string]interface{}{}
+	flattenedApplication["name"] = a.Name
+	flattenedApplication["version"] = a.Version
+	flattenedApplication["plan"] = a.Plan
+	flattenedApplication["category"] = a.Category
+	flattenedApplication["maintainer"] = a.Maintainer
+	flattenedApplication["installed"] = a.Installed
+	flattenedApplication["updated_at"] = ""
+	if !a.UpdatedAt.IsZero() {
+		flattenedApplication["updated_at"] = a.UpdatedAt.UTC().String()
+	}
+
+	return flattenedApplication, nil
+}
+
+func kubernetesApplicationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the application, as used in the `applications` of the cluster",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Description: "The version of the application",
+		},
+		"plan": {
+			Type:        schema.TypeString,
+			Description: "The plan of the application, if it has plans",
+		},
+		"category": {
+			Type:        schema.TypeString,
+			Description: "The category of the application, e.g. `database`",
+		},
+		"maintainer": {
+			Type:        schema.TypeString,
+			Description: "The maintainer of the application",
+		},
+		"installed": {
+			Type:        schema.TypeBool,
+			Description: "Whether the application has finished installing",
+		},
+		"updated_at": {
+			Type:        schema.TypeString,
+			Description: "When the application was last installed or updated",
+		},
+	}
+}
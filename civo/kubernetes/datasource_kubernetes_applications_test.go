@@ -0,0 +1,58 @@
+package kubernetes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoKubernetesApplications_basic(t *testing.T) {
+	datasourceName := "data.civo_kubernetes_applications.foobar"
+	name := acctest.RandomWithPrefix("k8s")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoKubernetesApplicationsConfig(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "applications.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "applications.0.name", "metrics-server"),
+					resource.TestCheckResourceAttrSet(datasourceName, "applications.0.version"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoKubernetesApplicationsConfig(name string) string {
+	return fmt.Sprintf(`
+data "civo_firewall" "default" {
+	name = "default-default"
+	region = "LON1"
+}
+
+resource "civo_kubernetes_cluster" "my-cluster" {
+	name = "%s"
+	firewall_id = data.civo_firewall.default.id
+	applications = "metrics-server"
+	pools {
+		node_count = 2
+		size = "g4s.kube.small"
+	}
+}
+
+data "civo_kubernetes_applications" "foobar" {
+	cluster_id = civo_kubernetes_cluster.my-cluster.id
+
+	filter {
+		key = "name"
+		values = ["metrics-server"]
+	}
+}
+`, name)
+}
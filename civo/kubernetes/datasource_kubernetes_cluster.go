@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -107,6 +108,16 @@ func DataSourceKubernetesCluster() *schema.Resource {
 				Computed:    true,
 				Description: "The IP of the Kubernetes master node",
 			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The network of the Kubernetes cluster",
+			},
+			"node_cidr": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CIDR block the nodes get their private IPs from, the one of the cluster's network",
+			},
 			"dns_entry": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -153,7 +164,7 @@ func dataSourceApplicationSchema() *schema.Schema {
 	}
 }
 
-func dataSourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func dataSourceKubernetesClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
 
 	// overwrite the region if it is defined in the datasource
@@ -163,21 +174,25 @@ func dataSourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData,
 
 	var foundCluster *civogo.KubernetesCluster
 
+	search := ""
 	if id, ok := d.GetOk("id"); ok {
 		log.Printf("[INFO] Getting the kubernetes Cluster by id")
-		kubeCluster, err := apiClient.FindKubernetesCluster(id.(string))
-		if err != nil {
-			return diag.Errorf("[ERR] failed to retrive kubernetes cluster: %s", err)
-		}
-		foundCluster = kubeCluster
+		search = id.(string)
 	} else if name, ok := d.GetOk("name"); ok {
 		log.Printf("[INFO] Getting the kubernetes Cluster by name")
-		kubeCluster, err := apiClient.FindKubernetesCluster(name.(string))
+		search = name.(string)
+	}
+
+	err := utils.RetryOnTransientError(ctx, utils.ReadRetryTimeout, func() error {
+		kubeCluster, err := apiClient.FindKubernetesCluster(search)
 		if err != nil {
-			return diag.Errorf("[ERR] failed to retrive kubernetes cluster: %s", err)
+			return err
 		}
-
 		foundCluster = kubeCluster
+		return nil
+	})
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrive kubernetes cluster: %s", err)
 	}
 
 	d.SetId(foundCluster.ID)
@@ -193,9 +208,16 @@ func dataSourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData,
 	d.Set("api_endpoint", foundCluster.APIEndPoint)
 	d.Set("master_ip", foundCluster.MasterIP)
 	d.Set("dns_entry", foundCluster.DNSEntry)
+	d.Set("network_id", foundCluster.NetworkID)
 	d.Set("created_at", foundCluster.CreatedAt.UTC().String())
 	d.Set("region", apiClient.Region)
 
+	nodeCIDR, err := findNodeCIDR(apiClient, foundCluster.NetworkID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to find the network of the kubernetes cluster: %s", err)
+	}
+	d.Set("node_cidr", nodeCIDR)
+
 	if err := d.Set("pools", flattenDataSourceNodePool(foundCluster)); err != nil {
 		return diag.Errorf("[ERR] error retrieving the pools for kubernetes cluster error: %#v", err)
 	}
@@ -27,6 +27,7 @@ func TestAccDataSourceCivoKubernetesCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(datasourceName, "kubeconfig"),
 					resource.TestCheckResourceAttrSet(datasourceName, "api_endpoint"),
 					resource.TestCheckResourceAttrSet(datasourceName, "master_ip"),
+					resource.TestCheckResourceAttrSet(datasourceName, "node_cidr"),
 				),
 			},
 		},
@@ -51,6 +52,7 @@ func TestAccDataSourceCivoKubernetesClusterByID_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(datasourceName, "kubeconfig"),
 					resource.TestCheckResourceAttrSet(datasourceName, "api_endpoint"),
 					resource.TestCheckResourceAttrSet(datasourceName, "master_ip"),
+					resource.TestCheckResourceAttrSet(datasourceName, "node_cidr"),
 				),
 			},
 		},
@@ -1,10 +1,12 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/civo/civogo"
 	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,7 +28,12 @@ func getKubernetesVersions(m interface{}, _ map[string]interface{}) ([]interface
 	apiClient := m.(*civogo.Client)
 
 	versions := []interface{}{}
-	partialVersions, err := apiClient.ListAvailableKubernetesVersions()
+	var partialVersions []civogo.KubernetesVersion
+	err := utils.RetryOnTransientError(context.Background(), utils.ReadRetryTimeout, func() error {
+		var err error
+		partialVersions, err = apiClient.ListAvailableKubernetesVersions()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("[ERR] error retrieving all versions: %s", err)
 	}
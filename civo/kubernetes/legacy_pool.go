@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceKubernetesClusterV0 is the part of the version 0 schema of civo_kubernetes_cluster the
+// state upgrader works on, from when the single node pool of a cluster could be described with
+// num_target_nodes and target_nodes_size rather than a pools block
+func resourceKubernetesClusterV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"num_target_nodes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"target_nodes_size": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"pools": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: nodePoolSchema(false),
+				},
+			},
+		},
+	}
+}
+
+// upgradeKubernetesClusterStateV0 fills the pools of a state which only has num_target_nodes and
+// target_nodes_size, so the first plan with a pools block is an update of the pool rather than
+// its creation, even without refreshing the state
+func upgradeKubernetesClusterStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	if pools, ok := rawState["pools"].([]interface{}); ok && len(pools) > 0 {
+		return rawState, nil
+	}
+
+	count := legacyNodeCount(rawState["num_target_nodes"])
+	size, _ := rawState["target_nodes_size"].(string)
+	if count == 0 || size == "" {
+		return rawState, nil
+	}
+
+	log.Printf("[INFO] moving num_target_nodes (%d) and target_nodes_size (%s) of the cluster %v into its pools", count, size, rawState["id"])
+	rawState["pools"] = []interface{}{
+		map[string]interface{}{
+			"label":               "",
+			"node_count":          count,
+			"size":                size,
+			"instance_names":      []interface{}{},
+			"public_ip_node_pool": false,
+		},
+	}
+
+	return rawState, nil
+}
+
+// legacyNodeCount returns num_target_nodes from a raw state, where it's a JSON number
+func legacyNodeCount(v interface{}) int {
+	switch count := v.(type) {
+	case float64:
+		return int(count)
+	case json.Number:
+		n, _ := count.Int64()
+		return int(n)
+	case int:
+		return count
+	}
+	return 0
+}
+
+// checkLegacyPoolArguments fails the plan when num_target_nodes or target_nodes_size are set but
+// don't match the pool, as they aren't applied anymore: the pool is only changed through pools
+func checkLegacyPoolArguments(d *schema.ResourceDiff) error {
+	pools := d.Get("pools").([]interface{})
+	if len(pools) == 0 || pools[0] == nil {
+		return nil
+	}
+	pool := pools[0].(map[string]interface{})
+	config := d.GetRawConfig()
+
+	if v := config.GetAttr("num_target_nodes"); !v.IsNull() && v.IsKnown() {
+		if count := d.Get("num_target_nodes").(int); count != pool["node_count"].(int) {
+			return fmt.Errorf("num_target_nodes (%d) is deprecated and isn't applied to the cluster, which has %d nodes in pools. "+
+				"Set node_count = %d in the pools block instead and remove num_target_nodes", count, pool["node_count"].(int), count)
+		}
+	}
+
+	if v := config.GetAttr("target_nodes_size"); !v.IsNull() && v.IsKnown() {
+		if size := d.Get("target_nodes_size").(string); size != pool["size"].(string) {
+			return fmt.Errorf("target_nodes_size (%s) is deprecated and isn't applied to the cluster, whose pool has the size %s. "+
+				"Set size = %q in the pools block instead and remove target_nodes_size", size, pool["size"].(string), size)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestResourceKubernetesClusterStateUpgradeV0(t *testing.T) {
+	legacyPools := func(count int, size string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"label":               "",
+				"node_count":          count,
+				"size":                size,
+				"instance_names":      []interface{}{},
+				"public_ip_node_pool": false,
+			},
+		}
+	}
+	existingPools := []interface{}{
+		map[string]interface{}{
+			"label":      "pool-1",
+			"node_count": float64(2),
+			"size":       "g4s.kube.small",
+		},
+	}
+
+	cases := []struct {
+		name  string
+		state map[string]interface{}
+		pools interface{}
+	}{
+		{
+			name: "float64 count without pools",
+			state: map[string]interface{}{
+				"id":                "cluster",
+				"num_target_nodes":  float64(3),
+				"target_nodes_size": "g4s.kube.medium",
+			},
+			pools: legacyPools(3, "g4s.kube.medium"),
+		},
+		{
+			name: "json.Number count without pools",
+			state: map[string]interface{}{
+				"id":                "cluster",
+				"num_target_nodes":  json.Number("2"),
+				"target_nodes_size": "g4s.kube.small",
+			},
+			pools: legacyPools(2, "g4s.kube.small"),
+		},
+		{
+			name: "empty pools",
+			state: map[string]interface{}{
+				"id":                "cluster",
+				"num_target_nodes":  float64(1),
+				"target_nodes_size": "g4s.kube.xsmall",
+				"pools":             []interface{}{},
+			},
+			pools: legacyPools(1, "g4s.kube.xsmall"),
+		},
+		{
+			name: "pools kept",
+			state: map[string]interface{}{
+				"id":                "cluster",
+				"num_target_nodes":  float64(3),
+				"target_nodes_size": "g4s.kube.medium",
+				"pools":             existingPools,
+			},
+			pools: existingPools,
+		},
+		{
+			name: "no size",
+			state: map[string]interface{}{
+				"id":               "cluster",
+				"num_target_nodes": json.Number("3"),
+			},
+			pools: nil,
+		},
+		{
+			name: "no count",
+			state: map[string]interface{}{
+				"id":                "cluster",
+				"target_nodes_size": "g4s.kube.medium",
+			},
+			pools: nil,
+		},
+	}
+
+	for _, c := range cases {
+		state, err := upgradeKubernetesClusterStateV0(context.Background(), c.state, nil)
+		if err != nil {
+			t.Fatalf("%s: upgradeKubernetesClusterStateV0 returned %s", c.name, err)
+		}
+		if !reflect.DeepEqual(state["pools"], c.pools) {
+			t.Errorf("%s: upgradeKubernetesClusterStateV0 set pools to %#v, want %#v", c.name, state["pools"], c.pools)
+		}
+	}
+
+	if state, err := upgradeKubernetesClusterStateV0(context.Background(), nil, nil); err != nil || state != nil {
+		t.Errorf("upgradeKubernetesClusterStateV0(nil) returned %v, %v, want nil, nil", state, err)
+	}
+}
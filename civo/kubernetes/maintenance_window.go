@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// weekdays are the days accepted in a maintenance window, in the order of time.Weekday
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// maintenanceWindow is the period of the week upgrades of a cluster are allowed in
+type maintenanceWindow struct {
+	days     map[time.Weekday]bool
+	start    time.Duration
+	duration time.Duration
+	force    bool
+}
+
+// maintenanceWindowSchema is the schema of the maintenance_window block
+func maintenanceWindowSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: strings.Join([]string{
+			"The period of the week the Kubernetes version of the cluster can be upgraded in.",
+			"Civo doesn't expose the scheduling of its own upgrades, so the window applies to the upgrades made by Terraform: a plan changing `kubernetes_version` outside of it fails, unless `force` is `true`.",
+		}, " "),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"days": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The days of the week the window starts on, e.g. `saturday`, every day if unspecified",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(weekdays, true),
+					},
+				},
+				"start_time": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateWindowStartTime,
+					Description:  "The time the window starts at, in UTC and in the `HH:MM` format, e.g. `02:00`",
+				},
+				"duration_hours": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      4,
+					ValidateFunc: validation.IntBetween(1, 24),
+					Description:  "How long the window lasts, in hours (the default is 4)",
+				},
+				"force": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Allow upgrading the cluster outside of the window, e.g. for an urgent security fix",
+				},
+			},
+		},
+	}
+}
+
+// validateWindowStartTime checks the start time is in the HH:MM format
+func validateWindowStartTime(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be string", k)}
+	}
+
+	if _, err := time.Parse("15:04", value); err != nil {
+		return nil, []error{fmt.Errorf("%s must be a time in the HH:MM format, e.g. 02:00. Got %s", k, value)}
+	}
+
+	return nil, nil
+}
+
+// expandMaintenanceWindow returns the configured maintenance window, or nil if there is none
+func expandMaintenanceWindow(raw []interface{}) *maintenanceWindow {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	block := raw[0].(map[string]interface{})
+
+	start, err := time.Parse("15:04", block["start_time"].(string))
+	if err != nil {
+		return nil
+	}
+
+	window := &maintenanceWindow{
+		days:     map[time.Weekday]bool{},
+		start:    time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		duration: time.Duration(block["duration_hours"].(int)) * time.Hour,
+		force:    block["force"].(bool),
+	}
+
+	if days, ok := block["days"].(*schema.Set); ok {
+		for _, day := range days.List() {
+			for i, weekday := range weekdays {
+				if strings.EqualFold(day.(string), weekday) {
+					window.days[time.Weekday(i)] = true
+				}
+			}
+		}
+	}
+
+	return window
+}
+
+// contains reports whether t is inside the window. A window can run past midnight, so the
+// one that started the day before is checked too
+func (w *maintenanceWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		if len(w.days) > 0 && !w.days[day.Weekday()] {
+			continue
+		}
+
+		start := day.Add(w.start)
+		if !t.Before(start) && t.Before(start.Add(w.duration)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String describes the window, e.g. "saturday, sunday from 02:00 UTC for 4h0m0s"
+func (w *maintenanceWindow) String() string {
+	days := []string{}
+	for i, weekday := range weekdays {
+		if w.days[time.Weekday(i)] {
+			days = append(days, weekday)
+		}
+	}
+	if len(days) == 0 {
+		days = []string{"every day"}
+	}
+
+	start := time.Time{}.Add(w.start).Format("15:04")
+	return fmt.Sprintf("%s from %s UTC for %s", strings.Join(days, ", "), start, w.duration)
+}
+
+// checkMaintenanceWindow returns an error if the cluster is upgraded outside of its maintenance window
+func checkMaintenanceWindow(raw []interface{}, now time.Time) error {
+	window := expandMaintenanceWindow(raw)
+	if window == nil || window.force || window.contains(now) {
+		return nil
+	}
+
+	return fmt.Errorf("the Kubernetes version can't be upgraded at %s, outside of the maintenance window (%s). "+
+		"Apply the change during the window, or set force = true in maintenance_window to upgrade now",
+		now.UTC().Format("Monday 15:04 UTC"), window)
+}
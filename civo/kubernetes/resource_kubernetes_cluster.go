@@ -2,7 +2,6 @@ package kubernetes
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"regexp"
@@ -12,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
 	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -50,7 +50,7 @@ func ResourceKubernetesCluster() *schema.Resource {
 				Optional:     true,
 				Computed:     true,
 				Deprecated:   "This field will be deprecated in the next major release, please use the 'pools' field instead",
-				Description:  "The number of instances to create (optional, the default at the time of writing is 3)",
+				Description:  "The number of nodes of the cluster, replaced by `node_count` in `pools`. It isn't applied to the cluster anymore, and the plan fails if it doesn't match the pool",
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"target_nodes_size": {
@@ -58,7 +58,7 @@ func ResourceKubernetesCluster() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 				Deprecated:  "This field will be deprecated in the next major release, please use the 'pools' field instead",
-				Description: "The size of each node (optional, the default is currently g4s.kube.medium)",
+				Description: "The size of each node, replaced by `size` in `pools`. It isn't applied to the cluster anymore, and the plan fails if it doesn't match the pool",
 			},
 			"kubernetes_version": {
 				Type:        schema.TypeString,
@@ -66,6 +66,7 @@ func ResourceKubernetesCluster() *schema.Resource {
 				Computed:    true,
 				Description: "The version of k3s to install (optional, the default is currently the latest stable available)",
 			},
+			"maintenance_window": maintenanceWindowSchema(),
 			"cni": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -147,6 +148,11 @@ func ResourceKubernetesCluster() *schema.Resource {
 				Computed:    true,
 				Description: "The IP address of the master node",
 			},
+			"node_cidr": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CIDR block the nodes get their private IPs from, the one of the cluster's network",
+			},
 			"dns_entry": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -171,6 +177,14 @@ func ResourceKubernetesCluster() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 		CustomizeDiff: customizeDiffKubernetesCluster,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceKubernetesClusterV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeKubernetesClusterStateV0,
+			},
+		},
 	}
 }
 
@@ -297,6 +311,7 @@ func resourceKubernetesClusterCreate(ctx context.Context, d *schema.ResourceData
 		Pending: []string{"BUILDING", "AVAILABLE", "UPGRADING", "SCALING"},
 		Target:  []string{"ACTIVE"},
 		Refresh: func() (interface{}, string, error) {
+			metrics.RecordRetry(ctx)
 			resp, err := apiClient.GetKubernetesCluster(d.Id())
 			if err != nil {
 				return 0, "", err
@@ -317,7 +332,7 @@ func resourceKubernetesClusterCreate(ctx context.Context, d *schema.ResourceData
 }
 
 // function to read the kubernetes cluster
-func resourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceKubernetesClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
 
 	// overwrite the region if it is defined in the datasource
@@ -326,9 +341,16 @@ func resourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData, m
 	}
 
 	log.Printf("[INFO] retrieving the kubernetes cluster %s", d.Id())
-	resp, err := apiClient.GetKubernetesCluster(d.Id())
+	var resp *civogo.KubernetesCluster
+	err := utils.RetryOnTransientError(ctx, utils.ReadRetryTimeout, func() error {
+		var err error
+		resp, err = apiClient.GetKubernetesCluster(d.Id())
+		return err
+	})
 	if err != nil {
-		if resp == nil {
+		// only remove the cluster from the state if the API is sure it doesn't exist
+		if utils.IsNotFoundError(err, civogo.DatabaseKubernetesClusterNotFoundError) {
+			log.Printf("[WARN] kubernetes cluster %s not found, removing it from the state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -350,6 +372,13 @@ func resourceKubernetesClusterRead(_ context.Context, d *schema.ResourceData, m
 	d.Set("api_endpoint", resp.APIEndPoint)
 	d.Set("master_ip", resp.MasterIP)
 	d.Set("dns_entry", resp.DNSEntry)
+
+	nodeCIDR, err := findNodeCIDR(apiClient, resp.NetworkID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to find the network of the kubernetes cluster: %s", err)
+	}
+	d.Set("node_cidr", nodeCIDR)
+
 	// d.Set("built_at", resp.BuiltAt.UTC().String())
 	d.Set("created_at", resp.CreatedAt.UTC().String())
 	d.Set("firewall_id", resp.FirewallID)
@@ -399,12 +428,15 @@ func resourceKubernetesClusterUpdate(ctx context.Context, d *schema.ResourceData
 
 	if d.HasChange("pools") {
 		old, new := d.GetChange("pools")
-		oldPool := old.([]interface{})[0].(map[string]interface{})
 		newPool := new.([]interface{})[0].(map[string]interface{})
 
-		// if the size is different, then return and error as we can't change the size of a pool
-		if oldPool["size"].(string) != newPool["size"].(string) {
-			return diag.Errorf("[ERR] Size change (%q) for existing cluster is not available at this moment", "size")
+		// if the size is different, then return and error as we can't change the size of a pool.
+		// A state from before pools has none, the pool is then matched by its label below
+		if oldPools := old.([]interface{}); len(oldPools) > 0 {
+			oldPool := oldPools[0].(map[string]interface{})
+			if oldPool["size"].(string) != newPool["size"].(string) {
+				return diag.Errorf("[ERR] Size change (%q) for existing cluster is not available at this moment", "size")
+			}
 		}
 
 		config.Region = apiClient.Region
@@ -427,6 +459,11 @@ func resourceKubernetesClusterUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	if d.HasChange("kubernetes_version") {
+		// the plan may have been made inside the window and applied after it
+		if err := checkMaintenanceWindow(d.Get("maintenance_window").([]interface{}), time.Now()); err != nil {
+			return diag.Errorf("[ERR] %s", err)
+		}
+
 		config.KubernetesVersion = d.Get("kubernetes_version").(string)
 		config.Region = apiClient.Region
 	}
@@ -494,9 +531,10 @@ func resourceKubernetesClusterDelete(ctx context.Context, d *schema.ResourceData
 		Pending: []string{"DELETING"},
 		Target:  []string{"DELETED"},
 		Refresh: func() (interface{}, string, error) {
+			metrics.RecordRetry(ctx)
 			resp, err := apiClient.GetKubernetesCluster(d.Id())
 			if err != nil {
-				if errors.Is(err, civogo.DatabaseKubernetesClusterNotFoundError) {
+				if utils.IsNotFoundError(err, civogo.DatabaseKubernetesClusterNotFoundError) {
 					return 0, "DELETED", nil
 				}
 				return 0, "", err
@@ -517,6 +555,9 @@ func resourceKubernetesClusterDelete(ctx context.Context, d *schema.ResourceData
 }
 
 func customizeDiffKubernetesCluster(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := checkLegacyPoolArguments(d); err != nil {
+		return err
+	}
 
 	// Check if cluster type is talos and CNI is cilium
 	if clusterType, ok := d.GetOk("cluster_type"); ok && clusterType.(string) == "talos" {
@@ -586,6 +627,12 @@ func customizeDiffKubernetesCluster(ctx context.Context, d *schema.ResourceDiff,
 			}
 		}
 
+		if d.HasChange("kubernetes_version") {
+			if err := checkMaintenanceWindow(d.Get("maintenance_window").([]interface{}), time.Now()); err != nil {
+				return err
+			}
+		}
+
 		if d.HasChange("applications") {
 			return fmt.Errorf("the 'applications' field is immutable")
 		}
@@ -598,3 +645,17 @@ func customizeDiffKubernetesCluster(ctx context.Context, d *schema.ResourceDiff,
 	}
 	return nil
 }
+
+// findNodeCIDR returns the CIDR of the cluster's network, which the nodes get their private IPs from
+func findNodeCIDR(apiClient *civogo.Client, networkID string) (string, error) {
+	if networkID == "" {
+		return "", nil
+	}
+
+	network, err := apiClient.GetNetwork(networkID)
+	if err != nil {
+		return "", err
+	}
+
+	return network.CIDR, nil
+}
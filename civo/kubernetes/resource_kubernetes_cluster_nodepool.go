@@ -2,7 +2,6 @@ package kubernetes
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -126,7 +125,7 @@ func resourceKubernetesClusterNodePoolCreate(ctx context.Context, d *schema.Reso
 }
 
 // function to read the kubernetes cluster
-func resourceKubernetesClusterNodePoolRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceKubernetesClusterNodePoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
 	clusterID := d.Get("cluster_id").(string)
 
@@ -134,9 +133,15 @@ func resourceKubernetesClusterNodePoolRead(_ context.Context, d *schema.Resource
 	var diags diag.Diagnostics
 
 	log.Printf("[INFO] retrieving the kubernetes cluster %s", clusterID)
-	resp, err := apiClient.GetKubernetesCluster(clusterID)
+	var resp *civogo.KubernetesCluster
+	err := utils.RetryOnTransientError(ctx, utils.ReadRetryTimeout, func() error {
+		var err error
+		resp, err = apiClient.GetKubernetesCluster(clusterID)
+		return err
+	})
 	if err != nil {
-		if resp == nil {
+		if utils.IsNotFoundError(err, civogo.DatabaseKubernetesClusterNotFoundError) {
+			log.Printf("[WARN] kubernetes cluster %s not found, removing the pool %s from the state", clusterID, d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -144,9 +149,15 @@ func resourceKubernetesClusterNodePoolRead(_ context.Context, d *schema.Resource
 	}
 
 	log.Printf("[INFO] retrieving the kubernetes cluster pool %s", d.Id())
-	respPool, err := apiClient.GetKubernetesClusterPool(clusterID, d.Id())
+	var respPool *civogo.KubernetesPool
+	err = utils.RetryOnTransientError(ctx, utils.ReadRetryTimeout, func() error {
+		var err error
+		respPool, err = apiClient.GetKubernetesClusterPool(clusterID, d.Id())
+		return err
+	})
 	if err != nil {
-		if resp == nil {
+		if utils.IsNotFoundError(err, civogo.DatabaseClusterPoolNotFoundError) {
+			log.Printf("[WARN] kubernetes cluster pool %s not found, removing it from the state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -285,7 +296,7 @@ func resourceKubernetesClusterNodePoolDelete(ctx context.Context, d *schema.Reso
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete)-time.Minute, func() *retry.RetryError {
 		_, err := apiClient.GetKubernetesClusterPool(getKubernetesCluster.ID, d.Id())
 		if err != nil {
-			if errors.Is(err, civogo.DatabaseClusterPoolNotFoundError) {
+			if utils.IsNotFoundError(err, civogo.DatabaseClusterPoolNotFoundError) {
 				log.Printf("[INFO] kubernetes node pool %s deleted", d.Id())
 				return nil
 			}
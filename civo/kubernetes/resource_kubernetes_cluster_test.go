@@ -2,7 +2,10 @@ package kubernetes_test
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/civo/civogo"
 	"github.com/civo/terraform-provider-civo/civo/acceptance"
@@ -41,6 +44,7 @@ func TestAccCivoKubernetesCluster_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resName, "kubeconfig"),
 					resource.TestCheckResourceAttrSet(resName, "api_endpoint"),
 					resource.TestCheckResourceAttrSet(resName, "master_ip"),
+					resource.TestCheckResourceAttrSet(resName, "node_cidr"),
 					resource.TestCheckResourceAttrSet(resName, "dns_entry"),
 					resource.TestCheckResourceAttrSet(resName, "created_at"),
 					resource.TestCheckResourceAttrSet(resName, "cluster_type"),
@@ -86,6 +90,60 @@ func TestAccCivoKubernetesClusterCNI(t *testing.T) {
 	})
 }
 
+func TestAccCivoKubernetesCluster_maintenanceWindow(t *testing.T) {
+	resName := "civo_kubernetes_cluster.foobar"
+	var kubernetesClusterName = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	// a window two days from now, so the test never runs inside it
+	day := strings.ToLower(time.Now().UTC().AddDate(0, 0, 2).Weekday().String())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoKubernetesClusterConfigMaintenanceWindow(kubernetesClusterName, day, "25:00"),
+				ExpectError: regexp.MustCompile("must be a time in the HH:MM format"),
+			},
+			{
+				Config: CivoKubernetesClusterConfigMaintenanceWindow(kubernetesClusterName, day, "02:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "maintenance_window.#", "1"),
+					resource.TestCheckResourceAttr(resName, "maintenance_window.0.start_time", "02:00"),
+					resource.TestCheckResourceAttr(resName, "maintenance_window.0.duration_hours", "4"),
+					resource.TestCheckResourceAttr(resName, "maintenance_window.0.force", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoKubernetesCluster_legacyPoolArguments(t *testing.T) {
+	resName := "civo_kubernetes_cluster.foobar"
+	var kubernetesClusterName = acctest.RandomWithPrefix("tf-test") + ".example"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: acceptance.CivoKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				// num_target_nodes isn't applied anymore, so a value other than the pool's is an error
+				Config:      CivoKubernetesClusterConfigLegacyPool(kubernetesClusterName, 3),
+				ExpectError: regexp.MustCompile("Set node_count = 3 in the pools block instead"),
+			},
+			{
+				Config: CivoKubernetesClusterConfigLegacyPool(kubernetesClusterName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "num_target_nodes", "2"),
+					resource.TestCheckResourceAttr(resName, "pools.0.node_count", "2"),
+				),
+			},
+		},
+	})
+}
+
 func CivoKubernetesClusterValues(kubernetes *civogo.KubernetesCluster, name string) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		if kubernetes.Name != name {
@@ -156,3 +214,44 @@ resource "civo_kubernetes_cluster" "foobar" {
 	cni = "cilium"
 }`, name, name)
 }
+
+func CivoKubernetesClusterConfigMaintenanceWindow(name, day, startTime string) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "default" {
+	name = "%s"
+	create_default_rules = true
+	region = "FAKE"
+}
+
+resource "civo_kubernetes_cluster" "foobar" {
+	name = "%s"
+	firewall_id = civo_firewall.default.id
+	pools {
+		node_count = 2
+		size = "g4s.kube.small"
+	}
+	maintenance_window {
+		days = ["%s"]
+		start_time = "%s"
+	}
+}`, name, name, day, startTime)
+}
+
+func CivoKubernetesClusterConfigLegacyPool(name string, numTargetNodes int) string {
+	return fmt.Sprintf(`
+resource "civo_firewall" "default" {
+	name = "%s"
+	create_default_rules = true
+	region = "FAKE"
+}
+
+resource "civo_kubernetes_cluster" "foobar" {
+	name = "%s"
+	firewall_id = civo_firewall.default.id
+	num_target_nodes = %d
+	pools {
+		node_count = 2
+		size = "g4s.kube.small"
+	}
+}`, name, name, numTargetNodes)
+}
@@ -0,0 +1,254 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attachedResourcesSchema is the computed list of the resources that use a network
+func attachedResourcesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The resources (instances, Kubernetes clusters, load balancers and databases) still using the network",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the resource",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the resource",
+				},
+				"type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The type of the resource, one of `instance`, `kubernetes_cluster`, `loadbalancer` or `database`",
+				},
+			},
+		},
+	}
+}
+
+// networkUsage is what uses a network: the attached resources, sorted by type and name,
+// and the private IPs they hold in it
+type networkUsage struct {
+	attached   []map[string]interface{}
+	privateIPs map[string]bool
+}
+
+// getNetworkUsage returns what uses the network among the resources of the current region
+func getNetworkUsage(apiClient *civogo.Client, networkID string) (*networkUsage, error) {
+	usage := &networkUsage{
+		attached:   []map[string]interface{}{},
+		privateIPs: map[string]bool{},
+	}
+	add := func(resourceType, id, name, privateIP string) {
+		usage.attached = append(usage.attached, map[string]interface{}{
+			"id":   id,
+			"name": name,
+			"type": resourceType,
+		})
+		if privateIP != "" {
+			usage.privateIPs[privateIP] = true
+		}
+	}
+
+	instances, err := apiClient.ListAllInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %s", err)
+	}
+	for _, instance := range instances {
+		if instance.NetworkID == networkID {
+			add("instance", instance.ID, instance.Hostname, instance.PrivateIP)
+		}
+	}
+
+	clusters, err := apiClient.ListKubernetesClusters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Kubernetes clusters: %s", err)
+	}
+	for _, cluster := range clusters.Items {
+		if cluster.NetworkID == networkID {
+			add("kubernetes_cluster", cluster.ID, cluster.Name, "")
+		}
+	}
+
+	loadBalancers, err := apiClient.ListLoadBalancers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers: %s", err)
+	}
+	for _, loadBalancer := range loadBalancers {
+		if loadBalancer.NetworkID == networkID {
+			add("loadbalancer", loadBalancer.ID, loadBalancer.Name, loadBalancer.PrivateIP)
+		}
+	}
+
+	databases, err := apiClient.ListDatabases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %s", err)
+	}
+	for _, database := range databases.Items {
+		if database.NetworkID == networkID {
+			add("database", database.ID, database.Name, database.PrivateIPv4)
+		}
+	}
+
+	attached := usage.attached
+	sort.SliceStable(attached, func(i, j int) bool {
+		if attached[i]["type"] != attached[j]["type"] {
+			return attached[i]["type"].(string) < attached[j]["type"].(string)
+		}
+		return attached[i]["name"].(string) < attached[j]["name"].(string)
+	})
+
+	return usage, nil
+}
+
+// allocatedIPCount returns how many of the private IPs in use belong to the CIDR
+func (u *networkUsage) allocatedIPCount(cidr string) int {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return len(u.privateIPs)
+	}
+
+	count := 0
+	for ip := range u.privateIPs {
+		if parsed := net.ParseIP(ip); parsed != nil && ipNet.Contains(parsed) {
+			count++
+		}
+	}
+	return count
+}
+
+// usableIPCount returns how many addresses of an IPv4 CIDR can be given to resources, which is
+// all of them but the network and broadcast addresses, and the gateway when there is one
+func usableIPCount(cidr, gateway string) int {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if bits != 32 || bits-ones < 2 {
+		return 0
+	}
+
+	count := 1<<(bits-ones) - 2
+	if ip := net.ParseIP(gateway); ip != nil && ipNet.Contains(ip) {
+		count--
+	}
+	return count
+}
+
+// setNetworkCapacity sets the attributes about the addresses of the network, which don't need
+// any other call to the API
+func setNetworkCapacity(d *schema.ResourceData, network *civogo.Network) {
+	d.Set("gateway_ipv4", network.GatewayIPv4)
+	d.Set("usable_ip_count", usableIPCount(network.CIDR, network.GatewayIPv4))
+}
+
+// setNetworkUsage sets the attributes about what uses the network. It lists the resources of the
+// region, so it's best effort: when they can't be listed a warning is logged and the attributes
+// are left as they are
+func setNetworkUsage(d *schema.ResourceData, apiClient *civogo.Client, network *civogo.Network) {
+	usage, err := getNetworkUsage(apiClient, network.ID)
+	if err != nil {
+		log.Printf("[WARN] failed to list the resources attached to the network %s: %s", network.ID, err)
+		return
+	}
+
+	if err := d.Set("attached_resources", usage.attached); err != nil {
+		log.Printf("[WARN] error setting the attached resources of the network %s: %s", network.ID, err)
+		return
+	}
+	d.Set("allocated_ip_count", usage.allocatedIPCount(network.CIDR))
+}
+
+// networkUsageSchema returns the computed attributes set by setNetworkUsage
+func networkUsageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"attached_resources": attachedResourcesSchema(),
+		"allocated_ip_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "How many private IPs of the network are used by instances, load balancers and databases",
+		},
+	}
+}
+
+// networkCapacitySchema returns the computed attributes set by setNetworkCapacity
+func networkCapacitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"gateway_ipv4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The gateway IP of the network, as reported by the API. It's only reported for VLAN networks, and empty for the others",
+		},
+		"usable_ip_count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "How many IPs of the network's CIDR can be given to resources, all of them but the network and broadcast addresses, and the gateway when it's reported",
+		},
+	}
+}
+
+// releaseNetworkDependents checks that nothing still uses the network before it's deleted and
+// lists what does otherwise. With forceDestroy, the firewalls left in the network that no resource
+// uses are deleted too, without it they are left for the API to deal with, as before
+func releaseNetworkDependents(apiClient *civogo.Client, networkID string, forceDestroy bool) error {
+	usage, err := getNetworkUsage(apiClient, networkID)
+	if err != nil {
+		return err
+	}
+
+	blocking := []string{}
+	for _, r := range usage.attached {
+		blocking = append(blocking, fmt.Sprintf("%s %s (%s)", r["type"], r["name"], r["id"]))
+	}
+
+	firewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		return fmt.Errorf("failed to list firewalls: %s", err)
+	}
+
+	unused := []civogo.Firewall{}
+	for _, firewall := range firewalls {
+		if firewall.NetworkID != networkID {
+			continue
+		}
+		if firewall.InstanceCount > 0 || firewall.ClusterCount > 0 || firewall.LoadBalancerCount > 0 {
+			blocking = append(blocking, fmt.Sprintf("firewall %s (%s)", firewall.Name, firewall.ID))
+			continue
+		}
+		unused = append(unused, firewall)
+	}
+
+	if len(blocking) > 0 {
+		return fmt.Errorf("it is still used by %s", strings.Join(blocking, ", "))
+	}
+
+	if !forceDestroy {
+		return nil
+	}
+
+	for _, firewall := range unused {
+		log.Printf("[INFO] Deleting the firewall %s left in the network %s", firewall.ID, networkID)
+		_, err := apiClient.DeleteFirewall(firewall.ID)
+		if err != nil && !errors.Is(err, civogo.DatabaseFirewallNotFoundError) {
+			return fmt.Errorf("failed to delete the firewall %s: %s", firewall.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceDefaultFirewall function returns a schema.Resource that represents the default firewall of a network,
+// the `<network label>-default` one created with civo_network or by Civo for its own networks
+func DataSourceDefaultFirewall() *schema.Resource {
+	return &schema.Resource{
+		Description: strings.Join([]string{
+			"Retrieve the default firewall of a network for use in other resources, e.g. to add rules to it with `civo_firewall_rule`.",
+			"The default firewall is the one named after the network, `<network label>-default`, which `civo_network` and Civo create with the network. When there is no such firewall but the network has a single firewall, that one is returned.",
+		}, "\n\n"),
+		ReadContext: dataSourceDefaultFirewallRead,
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "The ID of the network to get the default firewall of",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region of the network",
+			},
+			// Computed resource
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the default firewall",
+			},
+		},
+	}
+}
+
+func dataSourceDefaultFirewallRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	networkID := d.Get("network_id").(string)
+
+	log.Printf("[INFO] Getting the default firewall of the network %s", networkID)
+	network, err := apiClient.GetNetwork(networkID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrive the network %s: %s", networkID, err)
+	}
+
+	firewall, err := findNetworkDefaultFirewall(apiClient, network)
+	if err != nil {
+		return diag.Errorf("[ERR] %s", err)
+	}
+
+	d.SetId(firewall.ID)
+	d.Set("name", firewall.Name)
+	d.Set("region", apiClient.Region)
+
+	return nil
+}
+
+// findNetworkDefaultFirewall returns the default firewall of the network, the one named after it,
+// or its only firewall if it has a single one
+func findNetworkDefaultFirewall(apiClient *civogo.Client, network *civogo.Network) (*civogo.Firewall, error) {
+	firewall, err := utils.FindDefaultFirewall(apiClient, network)
+	if err != nil || firewall != nil {
+		return firewall, err
+	}
+
+	firewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewalls: %s", err)
+	}
+
+	inNetwork := []civogo.Firewall{}
+	for _, firewall := range firewalls {
+		if firewall.NetworkID == network.ID {
+			inNetwork = append(inNetwork, firewall)
+		}
+	}
+	switch len(inNetwork) {
+	case 0:
+		return nil, fmt.Errorf("the network %s has no firewall", network.ID)
+	case 1:
+		return &inNetwork[0], nil
+	}
+
+	names := []string{}
+	for _, firewall := range inNetwork {
+		names = append(names, firewall.Name)
+	}
+	return nil, fmt.Errorf("the network %s has no firewall named %s and %d other firewalls (%s), please use the civo_firewall data source to pick one by name",
+		network.ID, utils.DefaultFirewallName(network.Label), len(inNetwork), strings.Join(names, ", "))
+}
@@ -0,0 +1,44 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoDefaultFirewall_basic(t *testing.T) {
+	datasourceName := "data.civo_default_firewall.foobar"
+	networkLabel := acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoDefaultFirewallConfig(networkLabel),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "id", "civo_network.foobar", "default_firewall_id"),
+					resource.TestCheckResourceAttr(datasourceName, "name", fmt.Sprintf("%s-default", networkLabel)),
+					resource.TestCheckResourceAttr(datasourceName, "region", "LON1"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoDefaultFirewallConfig(label string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%s"
+	region = "LON1"
+}
+
+data "civo_default_firewall" "foobar" {
+	network_id = civo_network.foobar.id
+	region = "LON1"
+}
+`, label)
+}
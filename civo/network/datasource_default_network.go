@@ -0,0 +1,62 @@
+package network
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceDefaultNetwork function returns a schema.Resource that represents the default Network of a region.
+// Every region has one, created by Civo, so it can be used without knowing its ID or importing it.
+func DataSourceDefaultNetwork() *schema.Resource {
+	return &schema.Resource{
+		Description: strings.Join([]string{
+			"Retrieve information about the default network of a region for use in other resources.",
+			"Every region has a default network created by Civo, which can't be deleted. This data source lets instances and clusters be attached to it without hard-coding its ID.",
+			"You can optionally pass region to get the default network of a region other than the one of the provider.",
+		}, "\n\n"),
+		ReadContext: dataSourceDefaultNetworkRead,
+		Schema: withNetworkAttributes(map[string]*schema.Schema{
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region to get the default network of",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The label of the default network",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the default network",
+			},
+		}),
+	}
+}
+
+func dataSourceDefaultNetworkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	log.Printf("[INFO] Getting the default network of the region %s", apiClient.Region)
+	network, err := apiClient.GetDefaultNetwork()
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrive the default network: %s", err)
+	}
+
+	setNetworkAttributes(d, network, apiClient.Region)
+
+	return setDataSourceNetworkUsage(d, apiClient, network)
+}
@@ -0,0 +1,37 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoDefaultNetwork_basic(t *testing.T) {
+	datasourceName := "data.civo_default_network.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoDefaultNetworkConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceName, "id"),
+					resource.TestCheckResourceAttr(datasourceName, "default", "true"),
+					resource.TestCheckResourceAttr(datasourceName, "region", "LON1"),
+					resource.TestCheckResourceAttrSet(datasourceName, "cidr_v4"),
+					resource.TestCheckResourceAttrSet(datasourceName, "usable_ip_count"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoDefaultNetworkConfig() string {
+	return `
+data "civo_default_network" "foobar" {
+	region = "LON1"
+}
+`
+}
@@ -2,7 +2,9 @@ package network
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"strings"
 
 	"github.com/civo/civogo"
@@ -12,49 +14,124 @@ import (
 )
 
 // DataSourceNetwork function returns a schema.Resource that represents a Network.
-// This can be used to query and retrieve details about a specific Network in the infrastructure using its id or label.
+// This can be used to query and retrieve details about a specific Network in the infrastructure using its id, label or name.
 func DataSourceNetwork() *schema.Resource {
 	return &schema.Resource{
 		Description: strings.Join([]string{
 			"Retrieve information about a network for use in other resources.",
 			"This data source provides all of the network's properties as configured on your Civo account.",
-			"Networks may be looked up by id or label, and you can optionally pass region if you want to make a lookup for a specific network inside that region.",
+			"Networks may be looked up by id, label or name, and you can optionally pass region if you want to make a lookup for a specific network inside that region.",
+			"They can also be looked up by their CIDR with `cidr_v4`, or by an IP address with `ip_address`, which returns the network whose CIDR contains it, e.g. to find the network of an instance from its private IP.",
 		}, "\n\n"),
 		ReadContext: dataSourceNetworkRead,
-		Schema: map[string]*schema.Schema{
+		Schema: withNetworkAttributes(map[string]*schema.Schema{
 			"id": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.NoZeroValues,
-				AtLeastOneOf: []string{"id", "label", "region"},
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
 			},
 			"label": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.NoZeroValues,
-				AtLeastOneOf: []string{"id", "label", "region"},
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
 				Description:  "The label of an existing network",
 			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
+				Description:  "The name of an existing network",
+			},
 			"region": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.NoZeroValues,
-				AtLeastOneOf: []string{"id", "label", "region"},
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
 				Description:  "The region of an existing network",
 			},
-			// Computed resource
-			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the network",
+			"cidr_v4": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDR,
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
+				Description:  "The CIDR block of an existing network, e.g. 192.168.1.0/24",
 			},
-			"default": {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "If is the default network",
+			"ip_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				AtLeastOneOf: []string{"id", "label", "name", "cidr_v4", "ip_address", "region"},
+				Description:  "An IP address in an existing network, the network whose CIDR block contains it is returned (the most specific one if several do)",
 			},
+		}),
+	}
+}
+
+// withNetworkAttributes adds the computed attributes shared by the network data sources to the given schema
+func withNetworkAttributes(s map[string]*schema.Schema) map[string]*schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"default": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "If is the default network",
+		},
+		"cidr_v4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The CIDR block of the network",
+		},
+		"nameservers_v4": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "List of nameservers of the network",
+		},
+		"vlan_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "VLAN ID of the network, only set for VLAN networks",
+		},
+		"vlan_gateway_ip_v4": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Gateway IP of the VLAN",
+		},
+		"vlan_physical_interface": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Physical interface of the VLAN",
+		},
+		"vlan_allocation_pool_v4_start": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Start of the IPv4 allocation pool of the VLAN",
+		},
+		"vlan_allocation_pool_v4_end": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "End of the IPv4 allocation pool of the VLAN",
 		},
 	}
+
+	// the attributes the data source looks networks up by are already in the schema
+	for k, v := range attributes {
+		if _, ok := s[k]; !ok {
+			s[k] = v
+		}
+	}
+	for k, v := range networkUsageSchema() {
+		s[k] = v
+	}
+	for k, v := range networkCapacitySchema() {
+		s[k] = v
+	}
+	return s
 }
 
 func dataSourceNetworkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -83,13 +160,155 @@ func dataSourceNetworkRead(_ context.Context, d *schema.ResourceData, m interfac
 		}
 
 		foundNetwork = network
+	} else if name, ok := d.GetOk("name"); ok {
+		log.Printf("[INFO] Getting the network by name")
+		network, err := findNetworkByName(apiClient, name.(string))
+		if err != nil {
+			return diag.Errorf("[ERR] failed to retrive network: %s", err)
+		}
+
+		foundNetwork = network
+	} else if cidr, ok := d.GetOk("cidr_v4"); ok {
+		log.Printf("[INFO] Getting the network by CIDR")
+		network, err := findNetworkByCIDR(apiClient, cidr.(string))
+		if err != nil {
+			return diag.Errorf("[ERR] failed to retrive network: %s", err)
+		}
+
+		foundNetwork = network
+	} else if ip, ok := d.GetOk("ip_address"); ok {
+		log.Printf("[INFO] Getting the network containing the IP address %s", ip)
+		network, err := findNetworkContainingIP(apiClient, ip.(string))
+		if err != nil {
+			return diag.Errorf("[ERR] failed to retrive network: %s", err)
+		}
+
+		foundNetwork = network
+	}
+
+	if foundNetwork == nil {
+		return diag.Errorf("[ERR] one of id, label, name, cidr_v4 or ip_address must be set to look up a network")
 	}
 
+	setNetworkAttributes(d, foundNetwork, apiClient.Region)
+
+	return setDataSourceNetworkUsage(d, apiClient, foundNetwork)
+}
+
+// setNetworkAttributes sets the attributes of a network data source from the API response
+func setNetworkAttributes(d *schema.ResourceData, foundNetwork *civogo.Network, region string) {
 	d.SetId(foundNetwork.ID)
 	d.Set("name", foundNetwork.Name)
 	d.Set("label", foundNetwork.Label)
-	d.Set("region", apiClient.Region)
+	d.Set("region", region)
 	d.Set("default", foundNetwork.Default)
+	d.Set("cidr_v4", foundNetwork.CIDR)
+	d.Set("nameservers_v4", foundNetwork.NameserversV4)
+	d.Set("vlan_id", foundNetwork.VlanID)
+	d.Set("vlan_gateway_ip_v4", foundNetwork.GatewayIPv4)
+	d.Set("vlan_physical_interface", foundNetwork.PhysicalInterface)
+	d.Set("vlan_allocation_pool_v4_start", foundNetwork.AllocationPoolV4Start)
+	d.Set("vlan_allocation_pool_v4_end", foundNetwork.AllocationPoolV4End)
+}
+
+// setDataSourceNetworkUsage sets the usage attributes on a network data source
+func setDataSourceNetworkUsage(d *schema.ResourceData, apiClient *civogo.Client, network *civogo.Network) diag.Diagnostics {
+	setNetworkCapacity(d, network)
+	setNetworkUsage(d, apiClient, network)
 
 	return nil
 }
+
+// findNetworkByName returns the network whose name is exactly the given one
+func findNetworkByName(apiClient *civogo.Client, name string) (*civogo.Network, error) {
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []civogo.Network
+	for _, network := range networks {
+		if network.Name == name {
+			found = append(found, network)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unable to find a network named %s", name)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("there are %d networks named %s, please use the id or the label instead", len(found), name)
+	}
+}
+
+// findNetworkByCIDR returns the network whose CIDR block is exactly the given one
+func findNetworkByCIDR(apiClient *civogo.Client, cidr string) (*civogo.Network, error) {
+	_, want, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []civogo.Network
+	for _, network := range networks {
+		if _, ipNet, err := net.ParseCIDR(network.CIDR); err == nil && ipNet.String() == want.String() {
+			found = append(found, network)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unable to find a network with the CIDR %s", cidr)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("there are %d networks with the CIDR %s, please use the id or the label instead", len(found), cidr)
+	}
+}
+
+// findNetworkContainingIP returns the network whose CIDR block contains the IP address,
+// the most specific one if the CIDR blocks of several networks contain it
+func findNetworkContainingIP(apiClient *civogo.Client, ip string) (*civogo.Network, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, fmt.Errorf("%s is not a valid IP address", ip)
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []civogo.Network
+	longestPrefix := -1
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network.CIDR)
+		if err != nil || !ipNet.Contains(addr) {
+			continue
+		}
+
+		prefix, _ := ipNet.Mask.Size()
+		switch {
+		case prefix > longestPrefix:
+			found = []civogo.Network{network}
+			longestPrefix = prefix
+		case prefix == longestPrefix:
+			found = append(found, network)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unable to find a network containing the IP address %s", ip)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("there are %d networks with the CIDR %s containing the IP address %s, please use the id or the label instead", len(found), found[0].CIDR, ip)
+	}
+}
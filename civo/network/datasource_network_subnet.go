@@ -0,0 +1,92 @@
+package network
+
+import (
+	"context"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceNetworkSubnet function returns a schema.Resource that represents a subnet of a Network.
+// This can be used to query and retrieve details about a specific subnet using its id or name.
+func DataSourceNetworkSubnet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieve information about a subnet of a network for use in other resources. Subnets may be looked up by id or name.",
+		ReadContext: dataSourceNetworkSubnetRead,
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the network the subnet belongs to",
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The name of the subnet",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The region of the subnet",
+			},
+			// Computed resource
+			"subnet_size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The size of the subnet allocated by Civo inside the network",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the subnet",
+			},
+		},
+	}
+}
+
+func dataSourceNetworkSubnetRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	networkID := d.Get("network_id").(string)
+
+	var search string
+	if id, ok := d.GetOk("id"); ok {
+		log.Printf("[INFO] Getting the subnet by id")
+		search = id.(string)
+	} else if name, ok := d.GetOk("name"); ok {
+		log.Printf("[INFO] Getting the subnet by name")
+		search = name.(string)
+	}
+
+	subnet, err := apiClient.FindSubnet(search, networkID)
+	if err != nil {
+		return diag.Errorf("[ERR] failed to retrive subnet: %s", err)
+	}
+
+	d.SetId(subnet.ID)
+	d.Set("name", subnet.Name)
+	d.Set("region", apiClient.Region)
+	d.Set("subnet_size", subnet.SubnetSize)
+	d.Set("status", subnet.Status)
+
+	return nil
+}
@@ -21,6 +21,27 @@ func TestAccDataSourceCivoNetwork_basic(t *testing.T) {
 				Config: DataSourceCivoNetworkConfig(name),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(datasourceName, "label", name),
+					resource.TestCheckResourceAttrSet(datasourceName, "cidr_v4"),
+					resource.TestCheckResourceAttrSet(datasourceName, "usable_ip_count"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCivoNetwork_byCIDR(t *testing.T) {
+	name := acctest.RandomWithPrefix("net-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoNetworkConfigByCIDR(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.civo_network.by_cidr", "id", "civo_network.foobar", "id"),
+					resource.TestCheckResourceAttrPair("data.civo_network.by_ip", "id", "civo_network.foobar", "id"),
+					resource.TestCheckResourceAttr("data.civo_network.by_ip", "cidr_v4", "10.99.42.0/24"),
 				),
 			},
 		},
@@ -40,3 +61,23 @@ data "civo_network" "foobar" {
 }
 `, name)
 }
+
+func DataSourceCivoNetworkConfigByCIDR(name string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%s"
+	region = "LON1"
+	cidr_v4 = "10.99.42.0/24"
+}
+
+data "civo_network" "by_cidr" {
+	cidr_v4 = civo_network.foobar.cidr_v4
+	region = "LON1"
+}
+
+data "civo_network" "by_ip" {
+	ip_address = cidrhost(civo_network.foobar.cidr_v4, 12)
+	region = "LON1"
+}
+`, name)
+}
@@ -0,0 +1,116 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/datalist"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceNetworks Data source to get and filter all networks in a region
+func DataSourceNetworks() *schema.Resource {
+	dataListConfig := &datalist.ResourceConfig{
+		Description:  "Get information on networks for use in other resources, with the ability to filter and sort the results. If no filters are specified, all networks in the region will be returned.",
+		RecordSchema: networksSchema(),
+		ExtraQuerySchema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "If used, all networks will be from the provided region",
+			},
+		},
+		ResultAttributeName: "networks",
+		FlattenRecord:       flattenDataSourceNetworks,
+		GetRecords:          getDataSourceNetworks,
+	}
+
+	return datalist.NewResource(dataListConfig)
+}
+
+func getDataSourceNetworks(m interface{}, extra map[string]interface{}) ([]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is define in the datasource
+	region, ok := extra["region"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to find `region` key from query data")
+	}
+
+	if region != "" {
+		apiClient.Region = region
+	}
+
+	networks := []interface{}{}
+	partialNetworks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("[ERR] error retrieving networks: %s", err)
+	}
+
+	for _, partialNetwork := range partialNetworks {
+		networks = append(networks, partialNetwork)
+	}
+
+	return networks, nil
+}
+
+func flattenDataSourceNetworks(network, m interface{}, _ map[string]interface{}) (map[string]interface{}, error) {
+	apiClient := m.(*civogo.Client)
+
+	n := network.(civogo.Network)
+
+	flattenedNetwork := map[string]interface{}{}
+	flattenedNetwork["id"] = n.ID
+	flattenedNetwork["name"] = n.Name
+	flattenedNetwork["label"] = n.Label
+	flattenedNetwork["region"] = apiClient.Region
+	flattenedNetwork["default"] = n.Default
+	flattenedNetwork["cidr_v4"] = n.CIDR
+	flattenedNetwork["nameservers_v4"] = n.NameserversV4
+	flattenedNetwork["status"] = n.Status
+	flattenedNetwork["vlan_id"] = n.VlanID
+
+	return flattenedNetwork, nil
+}
+
+func networksSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "ID of the network",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the network",
+		},
+		"label": {
+			Type:        schema.TypeString,
+			Description: "Label of the network",
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Description: "Region of the network",
+		},
+		"default": {
+			Type:        schema.TypeBool,
+			Description: "If is the default network",
+		},
+		"cidr_v4": {
+			Type:        schema.TypeString,
+			Description: "CIDR block of the network",
+		},
+		"nameservers_v4": {
+			Type:        schema.TypeList,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Nameservers of the network",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Description: "Status of the network",
+		},
+		"vlan_id": {
+			Type:        schema.TypeInt,
+			Description: "VLAN ID of the network, only set for VLAN networks",
+		},
+	}
+}
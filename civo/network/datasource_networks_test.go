@@ -0,0 +1,47 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCivoNetworks_basic(t *testing.T) {
+	datasourceName := "data.civo_networks.result"
+	name := acctest.RandomWithPrefix("net-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoNetworksConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "networks.#", "1"),
+					resource.TestCheckResourceAttr(datasourceName, "networks.0.label", name),
+					resource.TestCheckResourceAttrPair(datasourceName, "networks.0.id", "civo_network.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func DataSourceCivoNetworksConfig(name string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%s"
+	region = "LON1"
+}
+
+data "civo_networks" "result" {
+	region = "LON1"
+	filter {
+		key = "label"
+		values = [civo_network.foobar.label]
+	}
+}
+`, name)
+}
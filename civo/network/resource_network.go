@@ -1,29 +1,35 @@
 package network
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"log"
+	"net"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
 	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceNetwork function returns a schema.Resource that represents a Network.
 // This can be used to create, read, update, and delete operations for a Network in the infrastructure.
 func ResourceNetwork() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: "Provides a Civo network resource. This can be used to create, modify, and delete networks.",
 		Schema: map[string]*schema.Schema{
 			"label": {
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "Name for the network",
+				Description:  "Name for the network, changing it renames the default firewall of the network to `<label>-default` too",
 				ValidateFunc: utils.ValidateName,
 			},
 			"region": {
@@ -34,19 +40,21 @@ func ResourceNetwork() *schema.Resource {
 				Description: "The region of the network",
 			},
 			"cidr_v4": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "The CIDR block for the network",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "The CIDR block for the network",
 			},
 			"nameservers_v4": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsIPv4Address,
 				},
 				Computed:    true,
-				Description: "List of nameservers for the network",
+				Description: "List of IPv4 nameservers for the network, duplicates are ignored. The order is kept as configured even if the API returns them in another order",
 			},
 			// Computed resource
 			"name": {
@@ -59,36 +67,57 @@ func ResourceNetwork() *schema.Resource {
 				Computed:    true,
 				Description: "If the network is default, this will be `true`",
 			},
+			"default_firewall_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the default firewall created alongside the network",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the firewalls left in the network that no resource uses are deleted along with it (default: false). Deleting a network that instances, Kubernetes clusters, load balancers, databases or firewalls in use still depend on fails straight away, listing them",
+			},
+			"default_firewall_rules": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        defaultFirewallRuleSchema(),
+				Description: "The rules of the default firewall created alongside the network, if not defined the API default rules are used. Removing the block leaves the current rules in place",
+			},
 			// VLAN Network
 			"vlan_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "VLAN ID for the network",
+				Description: "VLAN ID for the network, changing it recreates the network",
 			},
 			"vlan_cidr_v4": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "CIDR for VLAN IPv4",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "CIDR for VLAN IPv4, changing it recreates the network",
 			},
 			"vlan_gateway_ip_v4": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Gateway IP for VLAN IPv4",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Gateway IP for VLAN IPv4, must be inside vlan_cidr_v4",
 			},
 			"vlan_physical_interface": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Physical interface for VLAN",
+				Description: "Physical interface for VLAN, changing it recreates the network",
 			},
 			"vlan_allocation_pool_v4_start": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Start of the IPv4 allocation pool for VLAN",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Start of the IPv4 allocation pool for VLAN, must be inside vlan_cidr_v4, changing it recreates the network",
 			},
 			"vlan_allocation_pool_v4_end": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "End of the IPv4 allocation pool for VLAN",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "End of the IPv4 allocation pool for VLAN, must be inside vlan_cidr_v4, changing it recreates the network",
 			},
 		},
 		CreateContext: resourceNetworkCreate,
@@ -101,10 +130,16 @@ func ResourceNetwork() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			State: resourceNetworkImport,
 		},
 		CustomizeDiff: customizeDiffNetwork,
 	}
+
+	for k, v := range networkCapacitySchema() {
+		r.Schema[k] = v
+	}
+
+	return r
 }
 
 // function to create a new network
@@ -117,24 +152,12 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	log.Printf("[INFO] creating the new network %s", d.Get("label").(string))
-	vlanConfig := civogo.VLANConnectConfig{
-		VlanID:                d.Get("vlan_id").(int),
-		PhysicalInterface:     d.Get("vlan_physical_interface").(string),
-		CIDRv4:                d.Get("vlan_cidr_v4").(string),
-		GatewayIPv4:           d.Get("vlan_gateway_ip_v4").(string),
-		AllocationPoolV4Start: d.Get("vlan_allocation_pool_v4_start").(string),
-		AllocationPoolV4End:   d.Get("vlan_allocation_pool_v4_end").(string),
-	}
-
 	configs := civogo.NetworkConfig{
 		Label:         d.Get("label").(string),
 		CIDRv4:        d.Get("cidr_v4").(string),
 		Region:        apiClient.Region,
-		NameserversV4: expandStringList(d.Get("nameservers_v4")),
-	}
-	// Only add VLAN configuration if VLAN ID is set
-	if vlanConfig.VlanID > 0 {
-		configs.VLanConfig = &vlanConfig
+		NameserversV4: uniqueStrings(expandStringList(d.Get("nameservers_v4"))),
+		VLanConfig:    expandVLANConfig(d),
 	}
 
 	log.Printf("[INFO] Attempting to create the network %s", d.Get("label").(string))
@@ -150,11 +173,31 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 	d.SetId(network.ID)
 	// Create a default firewall for the network
 	log.Printf("[INFO] Creating default firewall for the network %s", d.Get("label").(string))
-	err = createDefaultFirewall(apiClient, network.ID, network.Label)
+	firewallID, err := createDefaultFirewall(apiClient, network.ID, network.Label, expandDefaultFirewallRules(d))
 	if err != nil {
 		return diag.Errorf("[ERR] failed to create a new firewall for the network %s: %s", d.Get("label").(string), err)
 	}
-	return resourceNetworkRead(ctx, d, m)
+	d.Set("default_firewall_id", firewallID)
+
+	diags := resourceNetworkRead(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// two networks with overlapping ranges can't be peered or routed between later on, so let the user know
+	overlapping, err := findOverlappingNetworks(apiClient, network.ID, d.Get("cidr_v4").(string))
+	if err != nil {
+		log.Printf("[WARN] unable to check if the network %s overlaps with other networks: %s", network.ID, err)
+	}
+	for _, other := range overlapping {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Overlapping network CIDR",
+			Detail:   fmt.Sprintf("The CIDR %s of the network %s overlaps with the CIDR %s of the network %s (%s) in the region %s", d.Get("cidr_v4").(string), d.Get("label").(string), other.CIDR, other.Label, other.ID, apiClient.Region),
+		})
+	}
+
+	return diags
 }
 
 // function to read a network
@@ -166,31 +209,73 @@ func resourceNetworkRead(_ context.Context, d *schema.ResourceData, m interface{
 		apiClient.Region = region.(string)
 	}
 
-	CurrentNetwork := civogo.Network{}
-
 	log.Printf("[INFO] retriving the network %s", d.Id())
-	resp, err := apiClient.ListNetworks()
+	network, err := apiClient.GetNetwork(d.Id())
 	if err != nil {
-		if resp == nil {
+		if utils.IsNotFoundError(err, civogo.DatabaseNetworkNotFoundError) {
+			log.Printf("[WARN] network %s not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("[ERR] failed to list the network: %s", err)
+		return diag.Errorf("[ERR] failed to retrieve the network: %s", err)
+	}
+
+	d.Set("name", network.Name)
+	d.Set("region", apiClient.Region)
+	d.Set("label", network.Label)
+	d.Set("default", network.Default)
+	d.Set("cidr_v4", network.CIDR)
+	d.Set("nameservers_v4", normalizeNameservers(expandStringList(d.Get("nameservers_v4")), network.NameserversV4))
+
+	// The API doesn't echo back the VLAN CIDR, so vlan_cidr_v4 is kept as configured
+	if network.VlanID > 0 {
+		d.Set("vlan_id", network.VlanID)
+		d.Set("vlan_physical_interface", network.PhysicalInterface)
+		d.Set("vlan_gateway_ip_v4", network.GatewayIPv4)
+		d.Set("vlan_allocation_pool_v4_start", network.AllocationPoolV4Start)
+		d.Set("vlan_allocation_pool_v4_end", network.AllocationPoolV4End)
 	}
 
-	for _, net := range resp {
-		if net.ID == d.Id() {
-			CurrentNetwork = net
+	setNetworkCapacity(d, network)
+
+	// the default firewall in state is checked with its rules, civogo can't get a single firewall,
+	// and the firewalls are only listed to find it for networks created before default_firewall_id
+	// existed, imported ones, or when it was deleted outside of terraform
+	firewallID := d.Get("default_firewall_id").(string)
+	var rules []civogo.FirewallRule
+	if firewallID != "" {
+		rules, err = apiClient.ListFirewallRules(firewallID)
+		if err != nil {
+			if !utils.IsNotFoundError(err, civogo.DatabaseFirewallNotFoundError) {
+				return diag.Errorf("[ERR] failed to list the rules of the default firewall %s: %s", firewallID, err)
+			}
+			firewallID = ""
 		}
 	}
+	if firewallID == "" {
+		firewall, err := utils.FindDefaultFirewall(apiClient, network)
+		if err != nil {
+			return diag.Errorf("[ERR] failed to find the default firewall for the network %s: %s", d.Id(), err)
+		}
+		if firewall != nil {
+			firewallID = firewall.ID
+		}
+	}
+	d.Set("default_firewall_id", firewallID)
 
-	d.Set("name", CurrentNetwork.Name)
-	d.Set("region", apiClient.Region)
-	d.Set("label", CurrentNetwork.Label)
-	d.Set("default", CurrentNetwork.Default)
-	d.Set("cidr_v4", CurrentNetwork.CIDR)
-	d.Set("nameservers_v4", CurrentNetwork.NameserversV4)
+	// only track the rules when they are managed, otherwise the API defaults would show up as a diff
+	if _, ok := d.GetOk("default_firewall_rules"); ok && firewallID != "" {
+		if rules == nil {
+			rules, err = apiClient.ListFirewallRules(firewallID)
+			if err != nil {
+				return diag.Errorf("[ERR] failed to list the rules of the default firewall %s: %s", firewallID, err)
+			}
+		}
+		if err := d.Set("default_firewall_rules", flattenDefaultFirewallRules(rules)); err != nil {
+			return diag.Errorf("[ERR] error setting default firewall rules: %s", err)
+		}
+	}
 
 	return nil
 }
@@ -204,31 +289,61 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		apiClient.Region = region.(string)
 	}
 
+	// customizeDiffNetwork replaces the network when one of these changes, so reaching here means
+	// the change would be dropped by the API
+	for _, field := range vlanForceNewFields {
+		if d.HasChange(field) {
+			return diag.Errorf("[ERR] updating %s is not supported, the network %s has to be recreated", field, d.Id())
+		}
+	}
+
 	if d.HasChange("label") {
 		log.Printf("[INFO] updating the network %s", d.Id())
 		_, err := apiClient.RenameNetwork(d.Get("label").(string), d.Id())
 		if err != nil {
 			return diag.Errorf("[ERR] An error occurred while renaming the network %s", d.Id())
 		}
+
+		// the default firewall is found by its name where the state isn't known, e.g. by civo_firewall
+		if firewallID := d.Get("default_firewall_id").(string); firewallID != "" {
+			name := utils.DefaultFirewallName(d.Get("label").(string))
+			log.Printf("[INFO] renaming the default firewall %s of the network %s to %s", firewallID, d.Id(), name)
+			if _, err := apiClient.RenameFirewall(firewallID, &civogo.FirewallConfig{Name: name}); err != nil {
+				return diag.Errorf("[ERR] An error occurred while renaming the default firewall %s of the network %s: %s", firewallID, d.Id(), err)
+			}
+		}
 	}
 
 	networkConfig := civogo.NetworkConfig{
+		Label:         d.Get("label").(string),
 		Region:        apiClient.Region,
-		NameserversV4: expandStringList(d.Get("nameservers_v4")),
+		NameserversV4: uniqueStrings(expandStringList(d.Get("nameservers_v4"))),
+		VLanConfig:    expandVLANConfig(d),
 	}
 
-	if d.HasChange("nameservers_v4") {
-		log.Printf("[INFO] updating the network nameservers %s", d.Id())
+	if d.HasChanges("nameservers_v4", "vlan_gateway_ip_v4") {
+		log.Printf("[INFO] updating the network %s", d.Id())
 		_, err := apiClient.UpdateNetwork(d.Id(), networkConfig)
 		if err != nil {
-			return diag.Errorf("[ERR] An error occurred while updating the nameservers for the network %s: %s", d.Id(), err)
+			return diag.Errorf("[ERR] An error occurred while updating the network %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("default_firewall_rules") {
+		if _, ok := d.GetOk("default_firewall_rules"); ok {
+			firewallID := d.Get("default_firewall_id").(string)
+			log.Printf("[INFO] updating the rules of the default firewall %s", firewallID)
+			if err := reconcileDefaultFirewallRules(apiClient, firewallID, expandDefaultFirewallRules(d)); err != nil {
+				return diag.Errorf("[ERR] An error occurred while updating the rules of the default firewall %s: %s", firewallID, err)
+			}
 		}
 	}
+
 	return resourceNetworkRead(ctx, d, m)
 }
 
 // function to delete a network
-func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*civogo.Client)
 
 	// overwrite the region if is defined in the datasource
@@ -237,12 +352,30 @@ func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interfac
 	}
 
 	networkID := d.Id()
+
+	// The API refuses to delete a network that is still in use, which would keep the loop below
+	// retrying until it times out, so fail early with what is in the way instead. This is done
+	// first so a failure leaves the default firewall in place
+	if err := releaseNetworkDependents(apiClient, networkID, d.Get("force_destroy").(bool)); err != nil {
+		return diag.Errorf("[ERR] unable to delete the network %s: %s", networkID, err)
+	}
+
+	// The default firewall was created by us, so we remove it before the network
+	if firewallID := d.Get("default_firewall_id").(string); firewallID != "" {
+		log.Printf("[INFO] Deleting the default firewall %s of the network %s", firewallID, networkID)
+		_, err := apiClient.DeleteFirewall(firewallID)
+		if err != nil && !errors.Is(err, civogo.DatabaseFirewallNotFoundError) {
+			return diag.Errorf("[ERR] failed to delete the default firewall %s: %s", firewallID, err)
+		}
+	}
+
 	log.Printf("[INFO] Deleting the network %s", networkID)
 
 	deleteStateConf := &retry.StateChangeConf{
 		Pending: []string{"deleting", "exists"},
 		Target:  []string{"deleted"},
 		Refresh: func() (interface{}, string, error) {
+			metrics.RecordRetry(ctx)
 			// First, try to delete the network
 			resp, err := apiClient.DeleteNetwork(networkID)
 			if err != nil {
@@ -253,7 +386,7 @@ func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interfac
 				// Check if the network still exists
 				_, err := apiClient.GetNetwork(networkID)
 				if err != nil {
-					if errors.Is(err, civogo.DatabaseNetworkNotFoundError) {
+					if utils.IsNotFoundError(err, civogo.DatabaseNetworkNotFoundError) {
 						return resp, "deleted", nil
 					}
 					return nil, "", err
@@ -277,6 +410,66 @@ func resourceNetworkDelete(_ context.Context, d *schema.ResourceData, m interfac
 	return nil
 }
 
+// custom import to support region qualified IDs, e.g. LON1:b8ecd2ab-2267-4a5e-8692-cbf1d32583e3
+func resourceNetworkImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	apiClient := m.(*civogo.Client)
+
+	if !strings.Contains(d.Id(), ":") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	region, networkID, err := utils.ResourceCommonParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient.Region = region
+
+	log.Printf("[INFO] retriving the network %s in the region %s", networkID, region)
+	if _, err := apiClient.GetNetwork(networkID); err != nil {
+		return nil, fmt.Errorf("[ERR] failed to find the network %s in the region %s: %s", networkID, region, err)
+	}
+
+	d.SetId(networkID)
+	d.Set("region", region)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// uniqueStrings returns the list without its duplicates, keeping the first occurrence of each value
+func uniqueStrings(list []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// normalizeNameservers keeps the nameservers as they are in the state when the API returns the
+// same ones, only deduplicated or in another order, so that doesn't show up as a diff
+func normalizeNameservers(current, fromAPI []string) []string {
+	a, b := uniqueStrings(current), uniqueStrings(fromAPI)
+	if len(a) != len(b) {
+		return fromAPI
+	}
+
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return fromAPI
+		}
+	}
+
+	return current
+}
+
 func expandStringList(input interface{}) []string {
 	var result []string
 
@@ -290,26 +483,355 @@ func expandStringList(input interface{}) []string {
 	return result
 }
 
+// expandVLANConfig builds the VLAN configuration of the network, it returns nil if no VLAN ID is set
+func expandVLANConfig(d *schema.ResourceData) *civogo.VLANConnectConfig {
+	vlanID := d.Get("vlan_id").(int)
+	if vlanID <= 0 {
+		return nil
+	}
+
+	return &civogo.VLANConnectConfig{
+		VlanID:                vlanID,
+		PhysicalInterface:     d.Get("vlan_physical_interface").(string),
+		CIDRv4:                d.Get("vlan_cidr_v4").(string),
+		GatewayIPv4:           d.Get("vlan_gateway_ip_v4").(string),
+		AllocationPoolV4Start: d.Get("vlan_allocation_pool_v4_start").(string),
+		AllocationPoolV4End:   d.Get("vlan_allocation_pool_v4_end").(string),
+	}
+}
+
+// vlanForceNewFields are the VLAN attributes the API can't change on an existing network,
+// it accepts them on update but keeps the values the network was created with
+var vlanForceNewFields = []string{"vlan_id", "vlan_physical_interface", "vlan_cidr_v4", "vlan_allocation_pool_v4_start", "vlan_allocation_pool_v4_end"}
+
+// customizeDiffNetwork checks the CIDRs and the default firewall rules of the network, forces its
+// replacement when a VLAN setting the API can't change is changed, and logs a warning when a new
+// network overlaps with a network already in the region. The other networks of the configuration
+// can't be seen during a plan, they're only checked once they exist
 func customizeDiffNetwork(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
 	if d.Id() != "" && d.HasChange("cidr_v4") {
 		return fmt.Errorf("the 'cidr_v4' field is immutable")
 	}
+
+	if d.Id() == "" && d.NewValueKnown("cidr_v4") && d.Get("cidr_v4").(string) != "" {
+		logOverlappingNetworks(d, meta)
+	}
+
+	if err := validateVLANAddresses(d); err != nil {
+		return err
+	}
+
+	// tcp and udp default rules without ports have always been accepted, so only icmp ones are checked
+	for _, v := range d.Get("default_firewall_rules").(*schema.Set).List() {
+		rule := v.(map[string]interface{})
+		if rule["protocol"].(string) != "icmp" {
+			continue
+		}
+		if err := utils.ValidateFirewallRulePorts(rule["protocol"].(string), rule["port_range"].(string)); err != nil {
+			return fmt.Errorf("invalid default firewall rule %q: %s", rule["label"], err)
+		}
+	}
+
+	if d.Id() != "" {
+		for _, field := range vlanForceNewFields {
+			if d.HasChange(field) {
+				if err := d.ForceNew(field); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if changed := utils.ReplacingChanges(d, append([]string{"region"}, vlanForceNewFields...)...); len(changed) > 0 {
+		utils.LogReplacement(fmt.Sprintf("The network %s", d.Get("label")), changed, networkReplacementImpact(meta, d))
+	}
+
+	return nil
+}
+
+// networkReplacementImpact lists what's in the network to replace, as it can't be deleted while it's used
+func networkReplacementImpact(meta interface{}, d *schema.ResourceDiff) []string {
+	apiClient := meta.(*civogo.Client)
+
+	// the resources are in the region the network is in now
+	if region, _ := d.GetChange("region"); region.(string) != "" {
+		apiClient.Region = region.(string)
+	}
+
+	impact := []string{}
+
+	usage, err := getNetworkUsage(apiClient, d.Id())
+	if err != nil {
+		log.Printf("[WARN] unable to list the resources attached to the network %s: %s", d.Id(), err)
+	} else {
+		counts := map[string]int{}
+		for _, r := range usage.attached {
+			counts[r["type"].(string)]++
+		}
+		for _, kind := range []struct{ resourceType, name string }{
+			{"instance", "instances"},
+			{"kubernetes_cluster", "Kubernetes clusters"},
+			{"loadbalancer", "load balancers"},
+			{"database", "databases"},
+		} {
+			if counts[kind.resourceType] > 0 {
+				impact = append(impact, fmt.Sprintf("%d %s are in the network, it can't be deleted until they are deleted or replaced too", counts[kind.resourceType], kind.name))
+			}
+		}
+	}
+	impact = append(impact, "the default firewall of the network is deleted with it")
+
+	return impact
+}
+
+// validateVLANAddresses checks that the VLAN gateway and allocation pool are inside vlan_cidr_v4
+func validateVLANAddresses(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("vlan_cidr_v4") || d.Get("vlan_cidr_v4").(string) == "" {
+		return nil
+	}
+
+	_, vlanCIDR, err := net.ParseCIDR(d.Get("vlan_cidr_v4").(string))
+	if err != nil {
+		return fmt.Errorf("the 'vlan_cidr_v4' field is not a valid CIDR: %s", err)
+	}
+
+	for _, field := range []string{"vlan_gateway_ip_v4", "vlan_allocation_pool_v4_start", "vlan_allocation_pool_v4_end"} {
+		if !d.NewValueKnown(field) || d.Get(field).(string) == "" {
+			continue
+		}
+		if ip := net.ParseIP(d.Get(field).(string)); ip == nil || !vlanCIDR.Contains(ip) {
+			return fmt.Errorf("the '%s' field (%s) must be inside the 'vlan_cidr_v4' range %s", field, d.Get(field).(string), vlanCIDR)
+		}
+	}
+
+	start := net.ParseIP(d.Get("vlan_allocation_pool_v4_start").(string))
+	end := net.ParseIP(d.Get("vlan_allocation_pool_v4_end").(string))
+	if start != nil && end != nil && bytes.Compare(start.To4(), end.To4()) > 0 {
+		return fmt.Errorf("the 'vlan_allocation_pool_v4_start' field (%s) must not be after 'vlan_allocation_pool_v4_end' (%s)", start, end)
+	}
+
 	return nil
 }
 
-// createDefaultFirewall function to create a default firewall
-func createDefaultFirewall(apiClient *civogo.Client, networkID string, networkName string) error {
+// logOverlappingNetworks logs a warning for each network of the region the new network overlaps with.
+// The SDK can't attach warnings to a plan, this makes them stand out in the logs (TF_LOG=WARN)
+func logOverlappingNetworks(d *schema.ResourceDiff, meta interface{}) {
+	apiClient, ok := meta.(*civogo.Client)
+	if !ok {
+		return
+	}
+	if region := d.Get("region").(string); region != "" {
+		apiClient.Region = region
+	}
+
+	cidr := d.Get("cidr_v4").(string)
+	overlapping, err := findOverlappingNetworks(apiClient, "", cidr)
+	if err != nil {
+		log.Printf("[WARN] unable to check if the network %s overlaps with other networks: %s", d.Get("label"), err)
+		return
+	}
+	for _, other := range overlapping {
+		log.Printf("[WARN] The CIDR %s of the network %s overlaps with the CIDR %s of the network %s (%s) in the region %s", cidr, d.Get("label"), other.CIDR, other.Label, other.ID, apiClient.Region)
+	}
+}
+
+// findOverlappingNetworks returns the other networks of the region whose CIDR overlaps with the given one
+func findOverlappingNetworks(apiClient *civogo.Client, networkID string, cidr string) ([]civogo.Network, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	networks, err := apiClient.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	var overlapping []civogo.Network
+	for _, network := range networks {
+		if network.ID == networkID {
+			continue
+		}
+		_, otherNet, err := net.ParseCIDR(network.CIDR)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(otherNet.IP) || otherNet.Contains(ipNet.IP) {
+			overlapping = append(overlapping, network)
+		}
+	}
+
+	return overlapping, nil
+}
+
+// createDefaultFirewall function to create a default firewall, returns the ID of the new firewall.
+// If rules is empty the API creates its default rules
+func createDefaultFirewall(apiClient *civogo.Client, networkID string, networkName string, rules []civogo.FirewallRule) (string, error) {
 
 	firewallConfig := civogo.FirewallConfig{
-		Name:      fmt.Sprintf("%s-default", networkName),
+		Name:      utils.DefaultFirewallName(networkName),
 		NetworkID: networkID,
 		Region:    apiClient.Region,
 	}
 
+	if len(rules) > 0 {
+		createRules := false
+		firewallConfig.CreateRules = &createRules
+		firewallConfig.Rules = rules
+	}
+
 	// Create the default firewall
-	_, err := apiClient.NewFirewall(&firewallConfig)
+	firewall, err := apiClient.NewFirewall(&firewallConfig)
+	if err != nil {
+		return "", err
+	}
+	return firewall.ID, nil
+}
+
+func defaultFirewallRuleSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"label": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A string that will be the displayed name/reference for this rule",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The direction of the rule, `ingress` or `egress`",
+				ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "tcp",
+				Description:  "The protocol choice from `tcp`, `udp` or `icmp` (the default if unspecified is `tcp`)",
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp"}, false),
+			},
+			"port_range": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The port or port range to open, can be a single port or a range separated by a dash (`-`), e.g. `80` or `80-443`, with ports between 1 and 65535. Can't be set if the protocol is `icmp`",
+				ValidateFunc: utils.ValidatePortRange,
+			},
+			"cidr": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The CIDR notation of the other end to affect (e.g. 0.0.0.0/0 to open for everyone)",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The action of the rule, `allow` or `deny`",
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+		},
+	}
+}
+
+// expandDefaultFirewallRules returns the rules configured in default_firewall_rules
+func expandDefaultFirewallRules(d *schema.ResourceData) []civogo.FirewallRule {
+	var rules []civogo.FirewallRule
+
+	for _, v := range d.Get("default_firewall_rules").(*schema.Set).List() {
+		rule := v.(map[string]interface{})
+		rules = append(rules, civogo.FirewallRule{
+			Label:     rule["label"].(string),
+			Direction: rule["direction"].(string),
+			Protocol:  rule["protocol"].(string),
+			Ports:     rule["port_range"].(string),
+			Cidr:      expandStringList(rule["cidr"].(*schema.Set).List()),
+			Action:    rule["action"].(string),
+		})
+	}
+
+	return rules
+}
+
+// flattenDefaultFirewallRules flattens the rules of the default firewall
+func flattenDefaultFirewallRules(rules []civogo.FirewallRule) []interface{} {
+	flattenedRules := make([]interface{}, 0, len(rules))
+
+	for _, rule := range rules {
+		cidr := make([]interface{}, 0, len(rule.Cidr))
+		for _, c := range rule.Cidr {
+			cidr = append(cidr, c)
+		}
+
+		flattenedRules = append(flattenedRules, map[string]interface{}{
+			"label":      rule.Label,
+			"direction":  rule.Direction,
+			"protocol":   rule.Protocol,
+			"port_range": rule.Ports,
+			"cidr":       schema.NewSet(schema.HashString, cidr),
+			"action":     rule.Action,
+		})
+	}
+
+	return flattenedRules
+}
+
+// firewallRuleKey identifies a rule by its content, as the configured rules have no ID
+func firewallRuleKey(rule civogo.FirewallRule) string {
+	cidr := append([]string{}, rule.Cidr...)
+	sort.Strings(cidr)
+
+	return strings.Join([]string{rule.Direction, rule.Protocol, rule.Ports, strings.Join(cidr, ","), rule.Action, rule.Label}, "|")
+}
+
+// reconcileDefaultFirewallRules makes the rules of the firewall match the desired ones,
+// removing the rules that are not desired and creating the missing ones
+func reconcileDefaultFirewallRules(apiClient *civogo.Client, firewallID string, desired []civogo.FirewallRule) error {
+	current, err := apiClient.ListFirewallRules(firewallID)
 	if err != nil {
 		return err
 	}
+
+	desiredKeys := map[string]bool{}
+	for _, rule := range desired {
+		desiredKeys[firewallRuleKey(rule)] = true
+	}
+
+	currentKeys := map[string]bool{}
+	for _, rule := range current {
+		key := firewallRuleKey(rule)
+		if desiredKeys[key] {
+			currentKeys[key] = true
+			continue
+		}
+
+		log.Printf("[INFO] removing the rule %s from the default firewall %s", rule.ID, firewallID)
+		if _, err := apiClient.DeleteFirewallRule(firewallID, rule.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range desired {
+		if currentKeys[firewallRuleKey(rule)] {
+			continue
+		}
+
+		log.Printf("[INFO] adding a %s rule to the default firewall %s", rule.Direction, firewallID)
+		_, err := apiClient.NewFirewallRule(&civogo.FirewallRuleConfig{
+			FirewallID: firewallID,
+			Region:     apiClient.Region,
+			Protocol:   rule.Protocol,
+			Cidr:       rule.Cidr,
+			Direction:  rule.Direction,
+			Action:     rule.Action,
+			Label:      rule.Label,
+			Ports:      rule.Ports,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
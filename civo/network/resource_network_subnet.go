@@ -0,0 +1,149 @@
+package network
+
+import (
+	"context"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/utils"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceNetworkSubnet function returns a schema.Resource that represents a subnet inside a Network.
+// This can be used to create, read, and delete subnets of a Network in the infrastructure.
+func ResourceNetworkSubnet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Civo network subnet resource. This can be used to create and delete subnets inside a network.",
+		Schema: map[string]*schema.Schema{
+			"network_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: utils.ValidateUUID,
+				Description:  "The ID of the network the subnet belongs to",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the subnet",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "The region of the subnet",
+			},
+			// Computed resource
+			"subnet_size": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The size of the subnet allocated by Civo inside the network",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the subnet",
+			},
+		},
+		CreateContext: resourceNetworkSubnetCreate,
+		ReadContext:   resourceNetworkSubnetRead,
+		DeleteContext: resourceNetworkSubnetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkSubnetImport,
+		},
+	}
+}
+
+// function to create a new subnet
+func resourceNetworkSubnetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is defined in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	networkID := d.Get("network_id").(string)
+
+	// make sure the parent network exists in this region before asking for a subnet in it
+	if _, err := apiClient.GetNetwork(networkID); err != nil {
+		return diag.Errorf("[ERR] failed to find the network %s: %s", networkID, err)
+	}
+
+	log.Printf("[INFO] creating the subnet %s in the network %s", d.Get("name").(string), networkID)
+	subnet, err := apiClient.CreateSubnet(networkID, civogo.SubnetConfig{
+		Name: d.Get("name").(string),
+	})
+	if err != nil {
+		return diag.Errorf("[ERR] failed to create the subnet: %s", err)
+	}
+
+	d.SetId(subnet.ID)
+
+	return resourceNetworkSubnetRead(ctx, d, m)
+}
+
+// function to read a subnet
+func resourceNetworkSubnetRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is defined in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	log.Printf("[INFO] retriving the subnet %s", d.Id())
+	subnet, err := apiClient.GetSubnet(d.Get("network_id").(string), d.Id())
+	if err != nil {
+		if utils.IsNotFoundError(err, civogo.DatabaseNetworkNotFoundError) {
+			log.Printf("[WARN] subnet %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ERR] failed to retrieve the subnet: %s", err)
+	}
+
+	d.Set("name", subnet.Name)
+	d.Set("network_id", subnet.NetworkID)
+	d.Set("region", apiClient.Region)
+	d.Set("subnet_size", subnet.SubnetSize)
+	d.Set("status", subnet.Status)
+
+	return nil
+}
+
+// function to delete a subnet
+func resourceNetworkSubnetDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*civogo.Client)
+
+	// overwrite the region if is defined in the datasource
+	if region, ok := d.GetOk("region"); ok {
+		apiClient.Region = region.(string)
+	}
+
+	log.Printf("[INFO] deleting the subnet %s", d.Id())
+	_, err := apiClient.DeleteSubnet(d.Get("network_id").(string), d.Id())
+	if err != nil && !utils.IsNotFoundError(err, civogo.DatabaseNetworkNotFoundError) {
+		return diag.Errorf("[ERR] an error occurred while trying to delete the subnet %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// custom import to be able to add a subnet to terraform, the ID is network_id:subnet_id
+func resourceNetworkSubnetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	networkID, subnetID, err := utils.ResourceCommonParseID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(subnetID)
+	d.Set("network_id", networkID)
+
+	return []*schema.ResourceData{d}, nil
+}
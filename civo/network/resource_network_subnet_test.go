@@ -0,0 +1,51 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCivoNetworkSubnet_basic(t *testing.T) {
+	resName := "civo_network_subnet.foobar"
+	dataName := "data.civo_network_subnet.foobar"
+	networkLabel := acctest.RandomWithPrefix("tf-test")
+	subnetName := acctest.RandomWithPrefix("tf-subnet")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoNetworkSubnetConfigBasic(networkLabel, subnetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "name", subnetName),
+					resource.TestCheckResourceAttrPair(resName, "network_id", "civo_network.foobar", "id"),
+					resource.TestCheckResourceAttrPair(dataName, "id", resName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func CivoNetworkSubnetConfigBasic(label, name string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%s"
+}
+
+resource "civo_network_subnet" "foobar" {
+	network_id = civo_network.foobar.id
+	name = "%s"
+}
+
+data "civo_network_subnet" "foobar" {
+	network_id = civo_network_subnet.foobar.network_id
+	name = civo_network_subnet.foobar.name
+}
+`, label, name)
+}
@@ -2,6 +2,7 @@ package network_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/civo/civogo"
@@ -36,6 +37,8 @@ func TestAccCivoNetwork_basic(t *testing.T) {
 					// verify local values
 					resource.TestCheckResourceAttr(resName, "label", networkLabel),
 					resource.TestCheckResourceAttr(resName, "default", "false"),
+					resource.TestCheckResourceAttrSet(resName, "default_firewall_id"),
+					resource.TestCheckResourceAttrSet(resName, "usable_ip_count"),
 				),
 			},
 		},
@@ -75,6 +78,106 @@ func TestAccCivoNetwork_update(t *testing.T) {
 	})
 }
 
+func TestAccCivoNetwork_defaultFirewallRules(t *testing.T) {
+	resName := "civo_network.foobar"
+	var networkLabel = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoNetworkConfigDefaultFirewallRules(networkLabel, "22"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "default_firewall_rules.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "default_firewall_rules.*", map[string]string{
+						"port_range": "22",
+					}),
+				),
+			},
+			{
+				Config: CivoNetworkConfigDefaultFirewallRules(networkLabel, "2222"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "default_firewall_rules.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "default_firewall_rules.*", map[string]string{
+						"port_range": "2222",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoNetwork_forceDestroy(t *testing.T) {
+	var network civogo.Network
+	resName := "civo_network.foobar"
+	var networkLabel = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+			if _, err := client.GetNetwork(network.ID); err == nil {
+				return fmt.Errorf("network %s still exists", network.ID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: CivoNetworkConfigForceDestroy(networkLabel),
+				Check: resource.ComposeTestCheckFunc(
+					CivoNetworkResourceExists(resName, &network),
+					resource.TestCheckResourceAttr(resName, "force_destroy", "true"),
+					// a firewall Terraform doesn't know about, which force_destroy has to remove
+					func(_ *terraform.State) error {
+						client := acceptance.TestAccProvider.Meta().(*civogo.Client)
+						createRules := false
+						_, err := client.NewFirewall(&civogo.FirewallConfig{
+							Name:        networkLabel + "-unmanaged",
+							NetworkID:   network.ID,
+							Region:      client.Region,
+							CreateRules: &createRules,
+						})
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoNetwork_nameservers(t *testing.T) {
+	resName := "civo_network.foobar"
+	var networkLabel = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoNetworkConfigNameservers(networkLabel, `"8.8.8.8", "dns.google"`),
+				ExpectError: regexp.MustCompile(`expected nameservers_v4.1 to contain a valid IPv4 address`),
+			},
+			{
+				Config: CivoNetworkConfigNameservers(networkLabel, `"8.8.8.8", "1.1.1.1", "8.8.8.8"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "nameservers_v4.#", "3"),
+					resource.TestCheckResourceAttr(resName, "nameservers_v4.0", "8.8.8.8"),
+					resource.TestCheckResourceAttr(resName, "nameservers_v4.1", "1.1.1.1"),
+				),
+			},
+			{
+				// the duplicate and the order the API returns the nameservers in must not show up as a diff
+				Config:   CivoNetworkConfigNameservers(networkLabel, `"8.8.8.8", "1.1.1.1", "8.8.8.8"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func CivoNetworkValues(network *civogo.Network, name string) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		if network.Label != name {
@@ -141,6 +244,37 @@ resource "civo_network" "foobar" {
 }`, label)
 }
 
+func CivoNetworkConfigDefaultFirewallRules(label, port string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label = "%s"
+
+	default_firewall_rules {
+		label      = "ssh"
+		direction  = "ingress"
+		port_range = "%s"
+		cidr       = ["0.0.0.0/0"]
+		action     = "allow"
+	}
+}`, label, port)
+}
+
+func CivoNetworkConfigForceDestroy(label string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label         = "%s"
+	force_destroy = true
+}`, label)
+}
+
+func CivoNetworkConfigNameservers(label, nameservers string) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label          = "%s"
+	nameservers_v4 = [%s]
+}`, label, nameservers)
+}
+
 func CivoNetworkConfigUpdates(label string) string {
 	return fmt.Sprintf(`
 resource "civo_network" "foobar" {
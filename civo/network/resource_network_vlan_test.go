@@ -0,0 +1,170 @@
+package network_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/civo/acceptance"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// vlanTestNetwork holds the VLAN attributes of a civo_network under test
+type vlanTestNetwork struct {
+	label             string
+	vlanID            int
+	physicalInterface string
+	gateway           string
+	poolStart         string
+	poolEnd           string
+}
+
+// skipWithoutTestVLAN skips the test unless a VLAN usable for tests is configured.
+// CIVO_TEST_VLAN_ID and CIVO_TEST_VLAN_PHYSICAL_INTERFACE are always required, the extra
+// variables are only needed by the tests that move the network to another VLAN
+func skipWithoutTestVLAN(t *testing.T, extra ...string) {
+	for _, v := range append([]string{"CIVO_TEST_VLAN_ID", "CIVO_TEST_VLAN_PHYSICAL_INTERFACE"}, extra...) {
+		if os.Getenv(v) == "" {
+			t.Skipf("%s must be set for the VLAN acceptance tests", v)
+		}
+	}
+}
+
+func vlanTestEnvInt(t *testing.T, name string) int {
+	v, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		t.Fatalf("%s must be a number: %s", name, err)
+	}
+	return v
+}
+
+func newVLANTestNetwork(t *testing.T) vlanTestNetwork {
+	return vlanTestNetwork{
+		label:             acctest.RandomWithPrefix("tf-test"),
+		vlanID:            vlanTestEnvInt(t, "CIVO_TEST_VLAN_ID"),
+		physicalInterface: os.Getenv("CIVO_TEST_VLAN_PHYSICAL_INTERFACE"),
+		gateway:           "10.200.0.1",
+		poolStart:         "10.200.0.10",
+		poolEnd:           "10.200.0.100",
+	}
+}
+
+func TestAccCivoNetwork_vlanIDForcesNew(t *testing.T) {
+	skipWithoutTestVLAN(t, "CIVO_TEST_VLAN_ID_ALT")
+	before := newVLANTestNetwork(t)
+	after := before
+	after.vlanID = vlanTestEnvInt(t, "CIVO_TEST_VLAN_ID_ALT")
+
+	testAccCivoNetworkVLANTransition(t, before, after, "vlan_id", strconv.Itoa(after.vlanID), true)
+}
+
+func TestAccCivoNetwork_vlanPhysicalInterfaceForcesNew(t *testing.T) {
+	skipWithoutTestVLAN(t, "CIVO_TEST_VLAN_PHYSICAL_INTERFACE_ALT")
+	before := newVLANTestNetwork(t)
+	after := before
+	after.physicalInterface = os.Getenv("CIVO_TEST_VLAN_PHYSICAL_INTERFACE_ALT")
+
+	testAccCivoNetworkVLANTransition(t, before, after, "vlan_physical_interface", after.physicalInterface, true)
+}
+
+func TestAccCivoNetwork_vlanAllocationPoolStartForcesNew(t *testing.T) {
+	skipWithoutTestVLAN(t)
+	before := newVLANTestNetwork(t)
+	after := before
+	after.poolStart = "10.200.0.20"
+
+	testAccCivoNetworkVLANTransition(t, before, after, "vlan_allocation_pool_v4_start", after.poolStart, true)
+}
+
+func TestAccCivoNetwork_vlanAllocationPoolEndForcesNew(t *testing.T) {
+	skipWithoutTestVLAN(t)
+	before := newVLANTestNetwork(t)
+	after := before
+	after.poolEnd = "10.200.0.200"
+
+	testAccCivoNetworkVLANTransition(t, before, after, "vlan_allocation_pool_v4_end", after.poolEnd, true)
+}
+
+func TestAccCivoNetwork_vlanGatewayUpdatesInPlace(t *testing.T) {
+	skipWithoutTestVLAN(t)
+	before := newVLANTestNetwork(t)
+	after := before
+	after.gateway = "10.200.0.254"
+
+	testAccCivoNetworkVLANTransition(t, before, after, "vlan_gateway_ip_v4", after.gateway, false)
+}
+
+func TestAccCivoNetwork_vlanPoolOutsideCIDR(t *testing.T) {
+	skipWithoutTestVLAN(t)
+	network := newVLANTestNetwork(t)
+	network.poolEnd = "10.201.0.100"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      CivoNetworkConfigVLAN(network),
+				ExpectError: regexp.MustCompile(`'vlan_allocation_pool_v4_end' field \(10\.201\.0\.100\) must be inside`),
+			},
+		},
+	})
+}
+
+// testAccCivoNetworkVLANTransition applies before, then after, and checks that the network
+// was replaced (or kept) and that the changed attribute holds its new value
+func testAccCivoNetworkVLANTransition(t *testing.T, before, after vlanTestNetwork, attr, value string, replaced bool) {
+	var original, updated civogo.Network
+	resName := "civo_network.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoNetworkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoNetworkConfigVLAN(before),
+				Check:  CivoNetworkResourceExists(resName, &original),
+			},
+			{
+				Config: CivoNetworkConfigVLAN(after),
+				Check: resource.ComposeTestCheckFunc(
+					CivoNetworkResourceExists(resName, &updated),
+					CivoNetworkReplaced(&original, &updated, replaced),
+					resource.TestCheckResourceAttr(resName, attr, value),
+				),
+			},
+		},
+	})
+}
+
+// CivoNetworkReplaced checks whether the network got a new ID between two steps
+func CivoNetworkReplaced(before, after *civogo.Network, replaced bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if replaced && before.ID == after.ID {
+			return fmt.Errorf("expected the network %s to be replaced, but it was updated in place", before.ID)
+		}
+		if !replaced && before.ID != after.ID {
+			return fmt.Errorf("expected the network %s to be updated in place, but it was replaced by %s", before.ID, after.ID)
+		}
+		return nil
+	}
+}
+
+func CivoNetworkConfigVLAN(n vlanTestNetwork) string {
+	return fmt.Sprintf(`
+resource "civo_network" "foobar" {
+	label                         = "%s"
+	vlan_id                       = %d
+	vlan_physical_interface       = "%s"
+	vlan_cidr_v4                  = "10.200.0.0/24"
+	vlan_gateway_ip_v4            = "%s"
+	vlan_allocation_pool_v4_start = "%s"
+	vlan_allocation_pool_v4_end   = "%s"
+}`, n.label, n.vlanID, n.physicalInterface, n.gateway, n.poolStart, n.poolEnd)
+}
@@ -0,0 +1,114 @@
+package objectstorage
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withAWSProviderConfig adds the computed aws_provider_config block set by setAWSProviderConfig to the given schema
+func withAWSProviderConfig(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["aws_provider_config"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: strings.Join([]string{
+			"The credential shaped for the S3 configuration of the `hashicorp/aws` provider, to use the Object Store with it without any glue,",
+			"e.g. `access_key = civo_object_store_credential.backup.aws_provider_config[0].access_key`.",
+		}, " "),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"endpoint": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The S3 endpoint of the Object Stores in the region, as the API returns it for them, for `endpoints { s3 = ... }`",
+				},
+				"access_key": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The access key id of the credential",
+				},
+				"secret_key": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Sensitive:   true,
+					Description: "The secret access key of the credential",
+				},
+				"region": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The region of the Object Store, the AWS provider needs `skip_region_validation = true` to accept it",
+				},
+				"force_path_style": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Always `true`, path-style bucket URLs work with every bucket name on the Object Store, for `s3_use_path_style`",
+				},
+			},
+		},
+	}
+
+	return s
+}
+
+// objectStoreEndpoint returns the S3 endpoint of the Object Stores in the region. The credentials
+// aren't tied to a bucket, so it's taken from the Object Stores of the region the API returns, and
+// only built from the region when there is none yet
+func objectStoreEndpoint(apiClient *civogo.Client, accessKeyID string) string {
+	stores, err := apiClient.ListObjectStores()
+	if err != nil {
+		log.Printf("[WARN] failed to list the Object Stores to find their endpoint: %s", err)
+		return defaultObjectStoreEndpoint(apiClient.Region)
+	}
+
+	endpoint := storeEndpoint(stores.Items, accessKeyID)
+	if endpoint == "" {
+		log.Printf("[WARN] there is no Object Store in the region %s to take the endpoint from", apiClient.Region)
+		return defaultObjectStoreEndpoint(apiClient.Region)
+	}
+
+	return endpoint
+}
+
+// storeEndpoint returns the endpoint of the Object Stores as an URL, the one of a store owned by the
+// credential first, or an empty string when none has one
+func storeEndpoint(stores []civogo.ObjectStore, accessKeyID string) string {
+	endpoint := ""
+	for _, store := range stores {
+		if store.BucketURL == "" {
+			continue
+		}
+		if store.OwnerInfo.AccessKeyID == accessKeyID {
+			endpoint = store.BucketURL
+			break
+		}
+		if endpoint == "" {
+			endpoint = store.BucketURL
+		}
+	}
+
+	if endpoint != "" && !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return endpoint
+}
+
+// defaultObjectStoreEndpoint returns the endpoint the Object Stores of the region are given
+func defaultObjectStoreEndpoint(region string) string {
+	return fmt.Sprintf("https://objectstore.%s.civo.com", strings.ToLower(region))
+}
+
+// setAWSProviderConfig sets the aws_provider_config block from the credential
+func setAWSProviderConfig(d *schema.ResourceData, apiClient *civogo.Client, credential *civogo.ObjectStoreCredential) error {
+	return d.Set("aws_provider_config", []interface{}{
+		map[string]interface{}{
+			"endpoint":         objectStoreEndpoint(apiClient, credential.AccessKeyID),
+			"access_key":       credential.AccessKeyID,
+			"secret_key":       credential.SecretAccessKeyID,
+			"region":           apiClient.Region,
+			"force_path_style": true,
+		},
+	})
+}
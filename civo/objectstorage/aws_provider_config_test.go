@@ -0,0 +1,56 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/civo/civogo"
+)
+
+// store returns an Object Store owned by the access key, with the endpoint
+func store(accessKeyID, endpoint string) civogo.ObjectStore {
+	return civogo.ObjectStore{
+		OwnerInfo: civogo.BucketOwner{AccessKeyID: accessKeyID},
+		BucketURL: endpoint,
+	}
+}
+
+func TestStoreEndpoint(t *testing.T) {
+	cases := []struct {
+		name     string
+		stores   []civogo.ObjectStore
+		endpoint string
+	}{
+		{
+			name: "no store",
+		},
+		{
+			name:     "store of another credential",
+			stores:   []civogo.ObjectStore{store("OTHER", "objectstore.lon1.civo.com")},
+			endpoint: "https://objectstore.lon1.civo.com",
+		},
+		{
+			name: "store of the credential first",
+			stores: []civogo.ObjectStore{
+				store("OTHER", "objectstore.lon1.civo.com"),
+				store("KEY", "objectstore-2.lon1.civo.com"),
+			},
+			endpoint: "https://objectstore-2.lon1.civo.com",
+		},
+		{
+			name:     "endpoint with a scheme",
+			stores:   []civogo.ObjectStore{store("KEY", "https://objectstore.fra1.civo.com")},
+			endpoint: "https://objectstore.fra1.civo.com",
+		},
+		{
+			name:     "store without an endpoint",
+			stores:   []civogo.ObjectStore{store("KEY", ""), store("OTHER", "objectstore.nyc1.civo.com")},
+			endpoint: "https://objectstore.nyc1.civo.com",
+		},
+	}
+
+	for _, c := range cases {
+		if got := storeEndpoint(c.stores, "KEY"); got != c.endpoint {
+			t.Errorf("%s: expected the endpoint %q, got %q", c.name, c.endpoint, got)
+		}
+	}
+}
@@ -14,7 +14,7 @@ import (
 // DataSourceObjectStore function returns a schema.Resource that represents an Object Store.
 // This can be used to query and retrieve details about a specific Object Store in the infrastructure using its id or name.
 func DataSourceObjectStore() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: strings.Join([]string{
 			"Get information of an Object Store for use in other resources. This data source provides all of the Object Store's properties as configured on your Civo account.",
 			"Note: This data source returns a single Object Store. When specifying a name, an error will be raised if more than one Object Stores with the same name found.",
@@ -60,6 +60,12 @@ func DataSourceObjectStore() *schema.Resource {
 			},
 		},
 	}
+
+	for k, v := range objectStoreUsageSchema() {
+		r.Schema[k] = v
+	}
+
+	return r
 }
 
 func dataSourceObjectStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -100,5 +106,7 @@ func dataSourceObjectStoreRead(ctx context.Context, d *schema.ResourceData, m in
 	d.Set("bucket_url", foundStore.BucketURL)
 	d.Set("status", foundStore.Status)
 
+	setObjectStoreUsage(apiClient, d)
+
 	return nil
 }
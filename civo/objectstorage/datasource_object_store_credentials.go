@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,7 +21,7 @@ func DataSourceObjectStoreCredential() *schema.Resource {
 			"Note: This data source returns a single Object Store Credential. When specifying a name, an error will be raised if more than one Object Store Credentials with the same name found.",
 		}, "\n\n"),
 		ReadContext: dataSourceObjectStoreCredentialRead,
-		Schema: map[string]*schema.Schema{
+		Schema: withAWSProviderConfig(map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -53,7 +54,7 @@ func DataSourceObjectStoreCredential() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the Object Store Credential",
 			},
-		},
+		}),
 	}
 }
 
@@ -91,8 +92,12 @@ func dataSourceObjectStoreCredentialRead(ctx context.Context, d *schema.Resource
 	d.Set("name", foundStoreCredential.Name)
 	d.Set("region", apiClient.Region)
 	d.Set("access_key_id", foundStoreCredential.AccessKeyID)
+	redact.Register(foundStoreCredential.SecretAccessKeyID)
 	d.Set("secret_access_key", foundStoreCredential.SecretAccessKeyID)
 	d.Set("status", foundStoreCredential.Status)
+	if err := setAWSProviderConfig(d, apiClient, foundStoreCredential); err != nil {
+		return diag.Errorf("[ERR] error setting the aws_provider_config: %s", err)
+	}
 
 	return nil
 }
@@ -24,6 +24,8 @@ func TestAccDataSourceCivoObjectStoreCredential_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(datasourceName, "access_key_id"),
 					resource.TestCheckResourceAttrSet(datasourceName, "secret_access_key"),
 					resource.TestCheckResourceAttr(datasourceName, "status", "ready"),
+					resource.TestCheckResourceAttrPair(datasourceName, "aws_provider_config.0.access_key", datasourceName, "access_key_id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "aws_provider_config.0.endpoint", "civo_object_store_credential.foobar", "aws_provider_config.0.endpoint"),
 				),
 			},
 		},
@@ -26,6 +26,8 @@ func TestAccDataSourceCivoObjectStore_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(datasourceName, "bucket_url"),
 					resource.TestCheckResourceAttrSet(datasourceName, "access_key_id"),
 					resource.TestCheckResourceAttr(datasourceName, "status", "ready"),
+					resource.TestCheckResourceAttrSet(datasourceName, "max_size_kb"),
+					resource.TestCheckResourceAttr(datasourceName, "usage_percent", "0"),
 				),
 			},
 		},
@@ -10,12 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceObjectStore function returns a schema.Resource that represents an Object Store.
 // This can be used to create, read, update, and delete operations for an Object Store in the infrastructure.
 func ResourceObjectStore() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		Description: "Provides an Object Store resource. This can be used to create, modify, and delete object stores.",
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -52,6 +53,12 @@ func ResourceObjectStore() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the Object Store.",
 			},
+			"alert_threshold_percent": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+				Description:  "If set, Terraform shows a warning on every refresh where the space used in the Object Store is at or over this percentage of its maximum size. The Civo API has no alerting for Object Store usage, so no notification is sent outside of Terraform.",
+			},
 		},
 		CreateContext: resourceObjectStoreCreate,
 		ReadContext:   resourceObjectStoreRead,
@@ -64,6 +71,12 @@ func ResourceObjectStore() *schema.Resource {
 			Create: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
+
+	for k, v := range objectStoreUsageSchema() {
+		r.Schema[k] = v
+	}
+
+	return r
 }
 
 // Function to create an Object Store
@@ -145,7 +158,12 @@ func resourceObjectStoreRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("bucket_url", resp.BucketURL)
 	d.Set("status", resp.Status)
 
-	return nil
+	stats := setObjectStoreUsage(apiClient, d)
+	if stats == nil {
+		return nil
+	}
+
+	return usageAlert(resp.Name, stats, d.Get("alert_threshold_percent").(int))
 }
 
 // Function to update the Object Store
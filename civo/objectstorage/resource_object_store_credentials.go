@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/civo/civogo"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -17,7 +18,7 @@ import (
 func ResourceObjectStoreCredential() *schema.Resource {
 	return &schema.Resource{
 		Description: "Provides an Object Store Credential resource. This can be used to create, modify, and delete object stores credential.",
-		Schema: map[string]*schema.Schema{
+		Schema: withAWSProviderConfig(map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -47,7 +48,7 @@ func ResourceObjectStoreCredential() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the Object Store Credential.",
 			},
-		},
+		}),
 		CreateContext: resourceObjectStoreCredentialCreate,
 		ReadContext:   resourceObjectStoreCredentialRead,
 		UpdateContext: resourceObjectStoreCredentialUpdate,
@@ -138,8 +139,12 @@ func resourceObjectStoreCredentialRead(ctx context.Context, d *schema.ResourceDa
 
 	d.Set("name", resp.Name)
 	d.Set("access_key_id", resp.AccessKeyID)
+	redact.Register(resp.SecretAccessKeyID)
 	d.Set("secret_access_key", resp.SecretAccessKeyID)
 	d.Set("status", resp.Status)
+	if err := setAWSProviderConfig(d, apiClient, resp); err != nil {
+		return diag.Errorf("[ERR] error setting the aws_provider_config: %s", err)
+	}
 
 	return nil
 }
@@ -69,6 +69,11 @@ func TestAccCivoObjectStoreCredentialWhitCustomCredential_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resName, "access_key_id", "1234567890"),
 					resource.TestCheckResourceAttr(resName, "secret_access_key", "1234567890"),
 					resource.TestCheckResourceAttr(resName, "status", "ready"),
+					resource.TestCheckResourceAttr(resName, "aws_provider_config.#", "1"),
+					resource.TestCheckResourceAttr(resName, "aws_provider_config.0.access_key", "1234567890"),
+					resource.TestCheckResourceAttr(resName, "aws_provider_config.0.secret_key", "1234567890"),
+					resource.TestCheckResourceAttrSet(resName, "aws_provider_config.0.endpoint"),
+					resource.TestCheckResourceAttr(resName, "aws_provider_config.0.force_path_style", "true"),
 				),
 			},
 		},
@@ -40,6 +40,9 @@ func TestAccCivoObjectStore_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resName, "bucket_url"),
 					resource.TestCheckResourceAttrSet(resName, "access_key_id"),
 					resource.TestCheckResourceAttr(resName, "status", "ready"),
+					resource.TestCheckResourceAttr(resName, "num_objects", "0"),
+					resource.TestCheckResourceAttr(resName, "size_kb_utilised", "0"),
+					resource.TestCheckResourceAttrSet(resName, "max_size_kb"),
 				),
 			},
 		},
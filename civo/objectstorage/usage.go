@@ -0,0 +1,76 @@
+package objectstorage
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// objectStoreUsageSchema returns the computed attributes filled from the stats of the Object Store
+func objectStoreUsageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"size_kb_utilised": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The space used in the Object Store, in KB",
+		},
+		"max_size_kb": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The maximum size of the Object Store, in KB",
+		},
+		"num_objects": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of objects in the Object Store",
+		},
+		"usage_percent": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The space used in the Object Store as a percentage of its maximum size",
+		},
+	}
+}
+
+// usagePercent returns the space used as a percentage of the maximum size
+func usagePercent(stats *civogo.ObjectStoreStats) float64 {
+	if stats.MaxSizeKB <= 0 {
+		return 0
+	}
+	return float64(stats.SizeKBUtilised) * 100 / float64(stats.MaxSizeKB)
+}
+
+// setObjectStoreUsage sets the usage attributes of the Object Store. The stats are best-effort,
+// if they can't be read the usage attributes keep their previous values and nil is returned.
+func setObjectStoreUsage(apiClient *civogo.Client, d *schema.ResourceData) *civogo.ObjectStoreStats {
+	log.Printf("[INFO] retrieving the stats of the Object Store %s", d.Id())
+	stats, err := apiClient.GetObjectStoreStats(d.Id())
+	if err != nil {
+		log.Printf("[WARN] failed to retrieve the stats of the Object Store %s: %s", d.Id(), err)
+		return nil
+	}
+
+	d.Set("size_kb_utilised", stats.SizeKBUtilised)
+	d.Set("max_size_kb", stats.MaxSizeKB)
+	d.Set("num_objects", stats.NumObjects)
+	d.Set("usage_percent", usagePercent(stats))
+
+	return stats
+}
+
+// usageAlert returns a warning if the space used in the Object Store is at or over the threshold
+func usageAlert(name string, stats *civogo.ObjectStoreStats, threshold int) diag.Diagnostics {
+	usage := usagePercent(stats)
+	if threshold <= 0 || usage < float64(threshold) {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The Object Store %s is %.1f%% full", name, usage),
+		Detail:   fmt.Sprintf("%d KB of %d KB are used, which is at or over the alert_threshold_percent of %d%%", stats.SizeKBUtilised, stats.MaxSizeKB, threshold),
+	}}
+}
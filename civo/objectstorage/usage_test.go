@@ -0,0 +1,60 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/civo/civogo"
+)
+
+func TestUsageAlert(t *testing.T) {
+	cases := []struct {
+		name      string
+		stats     civogo.ObjectStoreStats
+		threshold int
+		percent   float64
+		warning   bool
+	}{
+		{
+			name:      "no threshold",
+			stats:     civogo.ObjectStoreStats{SizeKBUtilised: 900, MaxSizeKB: 1000},
+			threshold: 0,
+			percent:   90,
+		},
+		{
+			name:      "under the threshold",
+			stats:     civogo.ObjectStoreStats{SizeKBUtilised: 799, MaxSizeKB: 1000},
+			threshold: 80,
+			percent:   79.9,
+		},
+		{
+			name:      "at the threshold",
+			stats:     civogo.ObjectStoreStats{SizeKBUtilised: 800, MaxSizeKB: 1000},
+			threshold: 80,
+			percent:   80,
+			warning:   true,
+		},
+		{
+			name:      "over the threshold",
+			stats:     civogo.ObjectStoreStats{SizeKBUtilised: 1000, MaxSizeKB: 1000},
+			threshold: 80,
+			percent:   100,
+			warning:   true,
+		},
+		{
+			name:      "unknown maximum size",
+			stats:     civogo.ObjectStoreStats{SizeKBUtilised: 1000},
+			threshold: 80,
+			percent:   0,
+		},
+	}
+
+	for _, c := range cases {
+		if got := usagePercent(&c.stats); got != c.percent {
+			t.Errorf("%s: expected a usage of %v%%, got %v%%", c.name, c.percent, got)
+		}
+		diags := usageAlert("backup", &c.stats, c.threshold)
+		if got := len(diags) > 0; got != c.warning {
+			t.Errorf("%s: expected warning %t, got %v", c.name, c.warning, diags)
+		}
+	}
+}
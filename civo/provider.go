@@ -1,12 +1,12 @@
 package civo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
 
 	"github.com/civo/civogo"
 	"github.com/civo/terraform-provider-civo/civo/database"
@@ -23,11 +23,12 @@ import (
 	"github.com/civo/terraform-provider-civo/civo/size"
 	"github.com/civo/terraform-provider-civo/civo/ssh"
 	"github.com/civo/terraform-provider-civo/civo/volume"
+	"github.com/civo/terraform-provider-civo/internal/metrics"
+	"github.com/civo/terraform-provider-civo/internal/redact"
 	"github.com/civo/terraform-provider-civo/internal/utils"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/mitchellh/go-homedir"
 )
 
 var (
@@ -40,7 +41,7 @@ var (
 
 // Provider Civo cloud provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:             schema.TypeString,
@@ -56,6 +57,12 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("CIVO_CREDENTIAL_FILE", ""),
 				Description: "Path to the Civo credentials file. Can be specified using CIVO_CREDENTIAL_FILE environment variable.",
 			},
+			"token_command": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CIVO_TOKEN_COMMAND", ""),
+				Description: "A command printing the Civo API token on its standard output, e.g. to read it from a secret manager. Used when neither `CIVO_TOKEN` nor credentials_file are set. Can be specified using CIVO_TOKEN_COMMAND environment variable.",
+			},
 			"region": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -72,22 +79,32 @@ func Provider() *schema.Provider {
 		DataSourcesMap: map[string]*schema.Resource{
 			// "civo_template":           dataSourceTemplate(),
 			"civo_disk_image":              disk.DataSourceDiskImage(),
+			"civo_disk_images":             disk.DataSourceDiskImages(),
 			"civo_kubernetes_version":      kubernetes.DataSourceKubernetesVersion(),
 			"civo_kubernetes_cluster":      kubernetes.DataSourceKubernetesCluster(),
+			"civo_kubernetes_applications": kubernetes.DataSourceKubernetesApplications(),
 			"civo_size":                    size.DataSourceSize(),
 			"civo_instances":               instances.DataSourceInstances(),
 			"civo_instance":                instances.DataSourceInstance(),
+			"civo_instance_console_url":    instances.DataSourceInstanceConsoleURL(),
+			"civo_instance_usage":          instances.DataSourceInstanceUsage(),
 			"civo_dns_domain_name":         dns.DataSourceDNSDomainName(),
 			"civo_dns_domain_record":       dns.DataSourceDNSDomainRecord(),
 			"civo_network":                 network.DataSourceNetwork(),
+			"civo_default_network":         network.DataSourceDefaultNetwork(),
+			"civo_default_firewall":        network.DataSourceDefaultFirewall(),
+			"civo_networks":                network.DataSourceNetworks(),
+			"civo_network_subnet":          network.DataSourceNetworkSubnet(),
 			"civo_volume":                  volume.DataSourceVolume(),
 			"civo_firewall":                firewall.DataSourceFirewall(),
+			"civo_firewalls":               firewall.DataSourceFirewalls(),
 			"civo_loadbalancer":            loadbalancer.DataSourceLoadBalancer(),
 			"civo_ssh_key":                 ssh.DataSourceSSHKey(),
 			"civo_object_store":            objectstorage.DataSourceObjectStore(),
 			"civo_object_store_credential": objectstorage.DataSourceObjectStoreCredential(),
 			"civo_region":                  region.DataSourceRegion(),
 			"civo_reserved_ip":             ip.DataSourceReservedIP(),
+			"civo_ip":                      ip.DataSourceIP(),
 			"civo_database":                database.DataSourceDatabase(),
 			"civo_database_version":        database.DataDatabaseVersion(),
 		},
@@ -95,11 +112,13 @@ func Provider() *schema.Provider {
 			"civo_instance":                        instances.ResourceInstance(),
 			"civo_instance_reserved_ip_assignment": instances.ResourceInstanceReservedIPAssignment(),
 			"civo_network":                         network.ResourceNetwork(),
+			"civo_network_subnet":                  network.ResourceNetworkSubnet(),
 			"civo_volume":                          volume.ResourceVolume(),
 			"civo_volume_attachment":               volume.ResourceVolumeAttachment(),
 			"civo_dns_domain_name":                 dns.ResourceDNSDomainName(),
 			"civo_dns_domain_record":               dns.ResourceDNSDomainRecord(),
 			"civo_firewall":                        firewall.ResourceFirewall(),
+			"civo_firewall_rule":                   firewall.ResourceFirewallRule(),
 			"civo_ssh_key":                         ssh.ResourceSSHKey(),
 			"civo_kubernetes_cluster":              kubernetes.ResourceKubernetesCluster(),
 			"civo_kubernetes_node_pool":            kubernetes.ResourceKubernetesClusterNodePool(),
@@ -108,12 +127,30 @@ func Provider() *schema.Provider {
 			"civo_object_store_credential":         objectstorage.ResourceObjectStoreCredential(),
 			"civo_database":                        database.ResourceDatabase(),
 		},
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
+
+	// Log the timing of every operation so slow resources can be spotted in big workspaces
+	for name, r := range provider.ResourcesMap {
+		metrics.Instrument(name, metrics.KindResource, r)
+	}
+	for name, r := range provider.DataSourcesMap {
+		metrics.Instrument(name, metrics.KindDataSource, r)
+	}
+
+	// Make sure no secret ends up in a diagnostic
+	for _, r := range provider.ResourcesMap {
+		redact.Instrument(r)
+	}
+	for _, r := range provider.DataSourcesMap {
+		redact.Instrument(r)
+	}
+
+	return provider
 }
 
 // Provider configuration
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var regionValue, tokenValue, apiURL string
 	var client *civogo.Client
 	var err error
@@ -123,12 +160,14 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		regionValue = region.(string)
 	}
 
-	if token, source, err := getToken(d); err == nil {
-		tokenValue = token.(string)
-		tokenSource = source
-	} else {
-		return nil, fmt.Errorf("[ERR] No token configuration found in $CIVO_TOKEN or credentials_file or ~/.civo.json. Please go to https://dashboard.civo.com/security to fetch one: %v", err)
+	redact.WrapLogOutput()
+
+	tokenValue, tokenSource, err = resolveToken(d)
+	if err != nil {
+		return nil, diag.Errorf("[ERR] unable to get a Civo token, %v. Please go to https://dashboard.civo.com/security to fetch one", err)
 	}
+	redact.Register(tokenValue)
+	log.Printf("[INFO] using the Civo token from the %s", tokenSource)
 
 	if apiEndpoint, ok := d.GetOk("api_endpoint"); ok {
 		apiURL = apiEndpoint.(string)
@@ -137,7 +176,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 	client, err = civogo.NewClientWithURL(tokenValue, apiURL, regionValue)
 	if err != nil {
-		return nil, err
+		return nil, diag.FromErr(err)
 	}
 
 	userAgent := &civogo.Component{
@@ -152,48 +191,20 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
 		// Check if the error is DatabaseAccountNotFoundError
 		if errors.Is(err, civogo.DatabaseAccountNotFoundError) {
-			return nil, fmt.Errorf("the Civo token from %s is invalid. Please go to https://dashboard.civo.com/security to generate one", tokenSource)
+			return nil, diag.Errorf("the Civo token from the %s is invalid. Please go to https://dashboard.civo.com/security to generate one", tokenSource)
 		}
 
-		return nil, fmt.Errorf("an error occoured while connecting to Civo's API: %s", err)
+		return nil, diag.Errorf("an error occoured while connecting to Civo's API with the token from the %s: %s", tokenSource, err)
 	}
 
 	log.Printf("[DEBUG] Civo API URL: %s\n", apiURL)
-	return client, nil
-}
-
-func getToken(d *schema.ResourceData) (interface{}, string, error) {
-
-	// Gets you the token atrribute value or falls back to reading CIVO_TOKEN environment variable
-	if token, ok := d.GetOk("token"); ok {
-		return token, "environment variable", nil
-	}
-
-	// Check for credentials file specified in provider config
-	if credFile, ok := d.GetOk("credentials_file"); ok {
-		path, err := homedir.Expand(credFile.(string))
-		if err != nil {
-			return nil, "", fmt.Errorf("error expanding %v: %w", credFile, err)
-		}
-		token, err := readTokenFromFile(path)
-		if err == nil {
-			return token, "credentials file", nil
-		}
-		return nil, "", fmt.Errorf("error reading from credentials_file: %v", err)
-	}
-
-	// Check for default CLI config file
-	homeDir, err := homedir.Dir()
-	if err == nil {
-		token, err := readTokenFromFile(filepath.Join(homeDir, ".civo.json"))
-		if err == nil {
-			return token, "CLI config file", nil
-		}
-		return nil, "", fmt.Errorf("error reading from ~/.civo.json: %v", err)
-	}
-
-	return nil, "", err
 
+	// the source of the token is shown, to tell which credential a CI job really uses
+	return client, diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Using the Civo token from the %s", tokenSource),
+		Detail:   "The token is taken from the first of the token argument, the CIVO_TOKEN environment variable, the credentials file, the token command and the Civo CLI config file that is set.",
+	}}
 }
 
 func readTokenFromFile(path string) (string, error) {
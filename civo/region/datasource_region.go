@@ -35,6 +35,11 @@ func DataSourceRegion() *schema.Resource {
 				Computed:    true,
 				Description: "If the region is the default region, this will return `true`",
 			},
+			"gpu": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If the region offers GPU instances, this will return `true`",
+			},
 		},
 		ResultAttributeName: "regions",
 		FlattenRecord:       flattenRegions,
@@ -70,6 +75,7 @@ func flattenRegions(region, _ interface{}, _ map[string]interface{}) (map[string
 	flattenedRegion["name"] = s.Name
 	flattenedRegion["country"] = s.Country
 	flattenedRegion["default"] = s.Default
+	flattenedRegion["gpu"] = s.Features.GPU
 
 	return flattenedRegion, nil
 }
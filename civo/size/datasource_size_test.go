@@ -45,6 +45,24 @@ func TestAccDataSourceCivoSize_WithFilterAndSort(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceCivoSize_gpu(t *testing.T) {
+	datasourceName := "data.civo_size.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acceptance.TestAccPreCheck(t) },
+		Providers: acceptance.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: DataSourceCivoSizeConfigGPU(),
+				Check: resource.ComposeTestCheckFunc(
+					DataSourceCivoSizeExist(datasourceName),
+					DataSourceCivoSizeGPU(datasourceName),
+				),
+			},
+		},
+	})
+}
+
 func DataSourceCivoSizeExist(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -113,6 +131,34 @@ func DataSourceCivoSizeFilteredAndSorted(n string) resource.TestCheckFunc {
 	}
 }
 
+// DataSourceCivoSizeGPU checks all the sizes retrieved are GPU instance sizes
+func DataSourceCivoSizeGPU(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		total, err := strconv.Atoi(rs.Primary.Attributes["sizes.#"])
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < total; i++ {
+			name := rs.Primary.Attributes[fmt.Sprintf("sizes.%d.name", i)]
+			if rs.Primary.Attributes[fmt.Sprintf("sizes.%d.type", i)] != "instance" {
+				return fmt.Errorf("the size %s isn't an instance size", name)
+			}
+			if gpu, _ := strconv.Atoi(rs.Primary.Attributes[fmt.Sprintf("sizes.%d.gpu", i)]); gpu < 1 {
+				return fmt.Errorf("the size %s has no GPU", name)
+			}
+		}
+
+		return nil
+	}
+}
+
 func DataSourceCivoSizeConfig() string {
 	return `
 data "civo_size" "foobar" {
@@ -135,3 +181,20 @@ data "civo_size" "foobar" {
 }
 `
 }
+
+func DataSourceCivoSizeConfigGPU() string {
+	return `
+data "civo_size" "foobar" {
+	filter {
+		key = "type"
+		values = ["instance"]
+	}
+
+	filter {
+		key = "gpu_type"
+		values = [".+"]
+		match_by = "re"
+	}
+}
+`
+}
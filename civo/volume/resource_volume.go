@@ -40,6 +40,13 @@ func ResourceVolume() *schema.Resource {
 				Required:    true,
 				Description: "The network that the volume belongs to",
 			},
+			"bootable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "If set to true, the volume can be used as the root disk of an instance with its `boot_volume_id` (optional; default false)",
+			},
 			// Computed resource
 			"mount_point": {
 				Type:        schema.TypeString,
@@ -78,6 +85,7 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		SizeGigabytes: d.Get("size_gb").(int),
 		NetworkID:     d.Get("network_id").(string),
 		Region:        apiClient.Region,
+		Bootable:      d.Get("bootable").(bool),
 	}
 
 	if v, ok := d.GetOk("volume_type"); ok {
@@ -143,6 +151,7 @@ func resourceVolumeRead(_ context.Context, d *schema.ResourceData, m interface{}
 	d.Set("size_gb", resp.SizeGigabytes)
 	d.Set("mount_point", resp.MountPoint)
 	d.Set("volume_type", resp.VolumeType)
+	d.Set("bootable", resp.Bootable)
 
 	return nil
 }
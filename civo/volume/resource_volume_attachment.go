@@ -13,6 +13,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// The values of attach_phase
+const (
+	attachPhaseRunning = "running"
+	attachPhaseBoot    = "boot"
+)
+
 // ResourceVolumeAttachment function returns a schema.Resource that represents a Volume Attachment.
 // This can be used to create, read, update, and delete operations for a Volume Attachment in the infrastructure.
 func ResourceVolumeAttachment() *schema.Resource {
@@ -39,6 +45,15 @@ func ResourceVolumeAttachment() *schema.Resource {
 				ForceNew:    true,
 				Description: "The region for the volume attachment",
 			},
+			"attach_phase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      attachPhaseRunning,
+				ValidateFunc: validation.StringInSlice([]string{attachPhaseRunning, attachPhaseBoot}, false),
+				Description: "When the volume is attached: `running` attaches it to the running instance (the default), " +
+					"`boot` attaches it while the instance boots, rebooting it, so the volume is there for the mounts done at boot",
+			},
 		},
 		CreateContext: resourceVolumeAttachmentCreate,
 		ReadContext:   resourceVolumeAttachmentRead,
@@ -58,7 +73,7 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 
 	instanceID := d.Get("instance_id").(string)
 	volumeID := d.Get("volume_id").(string)
-	attachAtBoot := d.Get("attach_at_boot").(bool)
+	attachAtBoot := d.Get("attach_phase").(string) == attachPhaseBoot
 
 	log.Printf("[INFO] retrieving the volume %s", volumeID)
 	volume, err := apiClient.FindVolume(volumeID)
@@ -74,13 +89,6 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 		}
 
 		if attachAtBoot {
-			// Notify the terminal
-			msg := fmt.Sprintf("To use the volume %s, The instance %s needs to be rebooted", volumeID, instanceID)
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  msg,
-			})
-
 			vuc.AttachAtBoot = true
 		}
 
@@ -89,12 +97,33 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.Errorf("[ERR] error attaching volume to instance %s", err)
 		}
+
+		// the volume is only attached when the instance boots
+		if attachAtBoot {
+			log.Printf("[INFO] rebooting the instance %s to attach the volume %s at boot", instanceID, volumeID)
+			_, err := apiClient.RebootInstance(instanceID)
+			if err != nil {
+				return diag.Errorf("[ERR] error rebooting the instance %s to attach the volume %s at boot: %s", instanceID, volumeID, err)
+			}
+
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The instance %s was rebooted to attach the volume %s", instanceID, volumeID),
+				Detail:   "attach_phase is \"boot\", so the volume is attached while the instance boots",
+			})
+		}
 	}
 
 	d.SetId(resource.PrefixedUniqueId(fmt.Sprintf("%s-%s-", instanceID, volumeID)))
 
+	pending := []string{"attaching"}
+	if attachAtBoot {
+		// the volume stays available until the instance is back up
+		pending = append(pending, "available")
+	}
+
 	createStateConf := &resource.StateChangeConf{
-		Pending: []string{"attaching"},
+		Pending: pending,
 		Target:  []string{"attached"},
 		Refresh: func() (interface{}, string, error) {
 			resp, err := apiClient.FindVolume(volumeID)
@@ -113,7 +142,7 @@ func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("error waiting for volume (%s) to be attached: %s", d.Id(), err)
 	}
 
-	return resourceVolumeAttachmentRead(ctx, d, m)
+	return append(diags, resourceVolumeAttachmentRead(ctx, d, m)...)
 }
 
 // function to read the volume
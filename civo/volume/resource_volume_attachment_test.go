@@ -37,6 +37,27 @@ func TestAccCivoVolumeAttachment_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resName, "id"),
 					resource.TestCheckResourceAttrSet(resName, "instance_id"),
 					resource.TestCheckResourceAttrSet(resName, "volume_id"),
+					resource.TestCheckResourceAttr(resName, "attach_phase", "running"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCivoVolumeAttachment_attachAtBoot(t *testing.T) {
+	resName := "civo_volume_attachment.foobar"
+	var name = acctest.RandomWithPrefix("tf-test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acceptance.TestAccPreCheck(t) },
+		Providers:    acceptance.TestAccProviders,
+		CheckDestroy: CivoVolumeAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: CivoVolumeAttachmentConfigAttachPhase(name, "boot"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "attach_phase", "boot"),
+					resource.TestCheckResourceAttrPair(resName, "volume_id", "civo_volume.foo", "id"),
 				),
 			},
 		},
@@ -101,3 +122,33 @@ resource "civo_volume_attachment" "foobar" {
 }
 `, name, name)
 }
+
+func CivoVolumeAttachmentConfigAttachPhase(name, phase string) string {
+	return fmt.Sprintf(`
+# Query instance disk image
+data "civo_disk_image" "debian" {
+	filter {
+		key = "name"
+		values = ["debian-10"]
+	}
+}
+
+resource "civo_instance" "vm" {
+	hostname = "instance-%s"
+	size = "g3.small"
+	disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
+}
+
+resource "civo_volume" "foo" {
+	name = "%s"
+	size_gb = 10
+	network_id = civo_instance.vm.network_id
+}
+
+resource "civo_volume_attachment" "foobar" {
+	instance_id = civo_instance.vm.id
+	volume_id  = civo_volume.foo.id
+	attach_phase = "%s"
+}
+`, name, name, phase)
+}
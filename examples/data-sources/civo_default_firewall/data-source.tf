@@ -0,0 +1,17 @@
+data "civo_network" "production" {
+    label = "production"
+}
+
+data "civo_default_firewall" "production" {
+    network_id = data.civo_network.production.id
+}
+
+# Open the metrics port on the default firewall of the network
+resource "civo_firewall_rule" "metrics" {
+    firewall_id = data.civo_default_firewall.production.id
+    direction   = "ingress"
+    protocol    = "tcp"
+    port_range  = "9100"
+    cidr        = ["10.0.0.0/8"]
+    label       = "node-exporter"
+}
@@ -0,0 +1,9 @@
+data "civo_default_network" "lon1" {
+    region = "LON1"
+}
+
+resource "civo_firewall" "example" {
+    name       = "example-firewall"
+    region     = "LON1"
+    network_id = data.civo_default_network.lon1.id
+}
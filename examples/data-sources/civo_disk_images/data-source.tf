@@ -0,0 +1,18 @@
+# Newest Ubuntu image of the region
+data "civo_disk_images" "ubuntu" {
+    filter {
+        key = "distribution"
+        values = ["ubuntu"]
+    }
+
+    filter {
+        key = "most_recent"
+        values = ["true"]
+    }
+}
+
+resource "civo_instance" "my-test-instance" {
+    hostname = "foo.com"
+    firewall_id = civo_firewall.example.id
+    disk_image = element(data.civo_disk_images.ubuntu.disk_images, 0).id
+}
@@ -2,3 +2,10 @@ data "civo_firewall" "test" {
     name = "test-firewall"
     region = "LON1"
 }
+
+# Attach an instance to a firewall managed centrally
+resource "civo_instance" "app" {
+    hostname    = "app"
+    firewall_id = data.civo_firewall.test.id
+    network_id  = data.civo_firewall.test.network_id
+}
@@ -0,0 +1,18 @@
+# Firewalls of the production network, and the ones no resource uses
+data "civo_firewalls" "production" {
+    region      = "LON1"
+    network_id  = civo_network.production.id
+    name_prefix = "prod-"
+
+    sort {
+        key       = "name"
+        direction = "asc"
+    }
+}
+
+output "unused_firewalls" {
+    value = [
+        for f in data.civo_firewalls.production.firewalls : f.name
+        if f.instance_count == 0 && f.cluster_count == 0 && f.loadbalancer_count == 0
+    ]
+}
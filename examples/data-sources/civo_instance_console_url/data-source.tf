@@ -0,0 +1,8 @@
+data "civo_instance_console_url" "web" {
+    instance_id = civo_instance.web.id
+}
+
+output "web_console" {
+  value     = data.civo_instance_console_url.web.url
+  sensitive = true
+}
@@ -0,0 +1,7 @@
+data "civo_instance_usage" "web" {
+    instance_id = civo_instance.web.id
+}
+
+output "web_stats" {
+  value = data.civo_instance_usage.web.stats
+}
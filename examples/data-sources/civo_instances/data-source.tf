@@ -5,3 +5,30 @@ data "civo_instances" "small-size" {
         values = [g3.small]
     }
 }
+
+# The instances with both the web and production tags
+data "civo_instances" "production-web" {
+    filter {
+        key = "tags"
+        values = ["web", "production"]
+        all = true
+    }
+}
+
+# The running instances of a network, by hostname
+data "civo_instances" "network" {
+    filter {
+        key = "network_id"
+        values = [civo_network.custom_net.id]
+    }
+
+    filter {
+        key = "status"
+        values = ["ACTIVE"]
+    }
+
+    sort {
+        key = "hostname"
+        direction = "asc"
+    }
+}
@@ -0,0 +1,8 @@
+# What is the IP address seen in the logs?
+data "civo_ip" "suspect" {
+    address = "74.220.21.145"
+}
+
+output "suspect_owner" {
+    value = "${data.civo_ip.suspect.type} of ${data.civo_ip.suspect.owner_type} ${data.civo_ip.suspect.owner_name} (${data.civo_ip.suspect.owner_id})"
+}
@@ -0,0 +1,16 @@
+data "civo_kubernetes_applications" "my-cluster" {
+    cluster_id = civo_kubernetes_cluster.my-cluster.id
+
+    sort {
+        key       = "name"
+        direction = "asc"
+    }
+}
+
+# Applications installed on the cluster but not in its `applications`
+output "unmanaged_applications" {
+    value = [
+        for app in data.civo_kubernetes_applications.my-cluster.applications : app.name
+        if !contains(split(",", civo_kubernetes_cluster.my-cluster.applications), app.name)
+    ]
+}
@@ -1,6 +1,10 @@
 data "civo_network" "test" {
     label = "test-network"
     region = "LON1"
-    cidr_v4        = "10.0.0.0/24"
-    nameservers_v4 = ["8.8.8.8", "8.8.4.4", "1.1.1.1"]
+}
+
+# The network an IP address belongs to, e.g. the private IP of an instance
+data "civo_network" "by_ip" {
+    ip_address = "10.0.0.12"
+    region = "LON1"
 }
@@ -0,0 +1,4 @@
+data "civo_network_subnet" "app" {
+    network_id = civo_network.custom_net.id
+    name = "app"
+}
@@ -0,0 +1,11 @@
+data "civo_networks" "lon1" {
+    region = "LON1"
+    filter {
+        key = "default"
+        values = ["false"]
+    }
+    sort {
+        key = "label"
+        direction = "asc"
+    }
+}
@@ -0,0 +1,25 @@
+# The GPU instance sizes, e.g. to create an instance in a region with GPUs
+data "civo_size" "gpu" {
+    filter {
+        key = "type"
+        values = ["instance"]
+    }
+
+    filter {
+        key = "gpu_type"
+        values = [".+"]
+        match_by = "re"
+    }
+
+    sort {
+        key = "gpu"
+        direction = "asc"
+    }
+}
+
+data "civo_region" "gpu" {
+    filter {
+        key = "gpu"
+        values = ["true"]
+    }
+}
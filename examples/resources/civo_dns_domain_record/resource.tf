@@ -42,3 +42,13 @@ resource "civo_dns_domain_record" "www" {
     ttl = 600
     depends_on = [civo_dns_domain_name.mydomain, civo_instance.foo]
 }
+
+# Create an AAAA record for a dual-stack instance
+resource "civo_dns_domain_record" "www_v6" {
+    domain_id = civo_dns_domain_name.mydomain.id
+    type = "AAAA"
+    name = "www"
+    value = civo_instance.foo.ipv6_address
+    ttl = 600
+    depends_on = [civo_dns_domain_name.mydomain, civo_instance.foo]
+}
@@ -1,2 +1,8 @@
 # using ID
 terraform import civo_firewall.www b8ecd2ab-2267-4a5e-8692-cbf1d32583e3
+
+# using name, the rules of the firewall are imported as ingress_rule and egress_rule blocks
+terraform import civo_firewall.www www
+
+# using region and name (or ID), to import a firewall from a region other than the provider one
+terraform import civo_firewall.www LON1:www
@@ -37,6 +37,13 @@ resource "civo_firewall" "www" {
     action     = "allow"
   }
 
+  ingress_rule {
+    label      = "ping"
+    protocol   = "icmp"
+    cidr       = ["0.0.0.0/0"]
+    action     = "allow"
+  }
+
   egress_rule {
     label      = "all"
     protocol   = "tcp"
@@ -1,53 +1,23 @@
-# Query small instance size
-data "civo_size" "small" {
-    filter {
-        key = "name"
-        values = ["g3.small"]
-        match_by = "re"
-    }
-
-    filter {
-        key = "type"
-        values = ["instance"]
-    }
-
-}
-
-# Query instance disk image
-data "civo_disk_image" "debian" {
-   filter {
-        key = "name"
-        values = ["debian-10"]
-   }
-}
-
-# Create a new instance
-resource "civo_instance" "foo" {
-    hostname = "foo.com"
-    size = element(data.civo_size.small.sizes, 0).name
-    disk_image = element(data.civo_disk_image.debian.diskimages, 0).id
-}
-
 # Create a network
 resource "civo_network" "custom_net" {
     label = "my-custom-network"
 }
 
-# Create a firewall
+# Create an empty firewall, its rules can be added here or by other modules
 resource "civo_firewall" "custom_firewall" {
-  name = "my-custom-firewall"
-  network_id = civo_network.custom_net.id
+    name = "my-custom-firewall"
+    network_id = civo_network.custom_net.id
+    create_default_rules = false
 }
 
-# Create a firewall rule and only allow
-# connections from instance we created above
+# Add a rule to the firewall to allow connections
+# to a custom application from a single address
 resource "civo_firewall_rule" "custom_port" {
     firewall_id = civo_firewall.custom_firewall.id
-    protocol = "tcp"
-    start_port = "3000"
-    end_port = "3000"
-    cidr = [format("%s/%s",civo_instance.foo.public_ip,"32")]
     direction = "ingress"
+    protocol = "tcp"
+    port_range = "3000"
+    cidr = ["192.168.1.2/32"]
+    action = "allow"
     label = "custom-application"
-    depends_on = [civo_firewall.custom_firewall]
 }
@@ -1,2 +1,5 @@
 # using ID
 terraform import civo_network.custom_net b8ecd2ab-2267-4a5e-8692-cbf1d32583e3
+
+# using region and ID, to import a network from a region other than the provider one
+terraform import civo_network.custom_net LON1:b8ecd2ab-2267-4a5e-8692-cbf1d32583e3
@@ -1,3 +1,26 @@
 resource "civo_network" "custom_net" {
     label = "test_network"
 }
+
+# A network whose default firewall only allows SSH in
+resource "civo_network" "ssh_only" {
+    label = "ssh_only_network"
+
+    default_firewall_rules {
+        label      = "ssh"
+        direction  = "ingress"
+        protocol   = "tcp"
+        port_range = "22"
+        cidr       = ["0.0.0.0/0"]
+        action     = "allow"
+    }
+
+    default_firewall_rules {
+        label      = "all"
+        direction  = "egress"
+        protocol   = "tcp"
+        port_range = "1-65535"
+        cidr       = ["0.0.0.0/0"]
+        action     = "allow"
+    }
+}
@@ -0,0 +1,2 @@
+# using network ID and subnet ID
+terraform import civo_network_subnet.app b8ecd2ab-2267-4a5e-8692-cbf1d32583e3:f1e2d3c4-1b2a-4c3d-9e8f-0a1b2c3d4e5f
@@ -0,0 +1,8 @@
+resource "civo_network" "custom_net" {
+    label = "test_network"
+}
+
+resource "civo_network_subnet" "app" {
+    network_id = civo_network.custom_net.id
+    name = "app"
+}
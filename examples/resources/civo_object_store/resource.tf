@@ -2,6 +2,8 @@ resource "civo_object_store" "backup" {
 	name = "backup-server"
 	max_size_gb = 500
 	region = "LON1"
+	# show a warning on plan and apply once 80% of the space is used
+	alert_threshold_percent = 80
 }
 
 # If you create the bucket without credentials, you can read the credentials in this way
@@ -16,4 +16,21 @@ resource "civo_object_store" "backup" {
 	max_size_gb = 500
 	region = "LON1"
 	access_key_id = civo_object_store_credential.backup.access_key_id
-}
\ No newline at end of file
+}
+
+# Use the credential with the S3 resources of the AWS provider
+provider "aws" {
+	alias = "civo"
+	access_key = civo_object_store_credential.backup.aws_provider_config[0].access_key
+	secret_key = civo_object_store_credential.backup.aws_provider_config[0].secret_key
+	region = civo_object_store_credential.backup.aws_provider_config[0].region
+	s3_use_path_style = civo_object_store_credential.backup.aws_provider_config[0].force_path_style
+
+	skip_credentials_validation = true
+	skip_region_validation = true
+	skip_requesting_account_id = true
+
+	endpoints {
+		s3 = civo_object_store_credential.backup.aws_provider_config[0].endpoint
+	}
+}
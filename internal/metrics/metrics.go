@@ -0,0 +1,105 @@
+// Package metrics emits per-operation timing for the provider resources and data sources.
+// Everything is logged through tflog using a fixed set of keys, so the output of
+// TF_LOG=INFO (or TF_LOG_PROVIDER=INFO) can be grepped/parsed to find which resources
+// dominate the apply time.
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Keys used in every operation log entry
+const (
+	KeyResource   = "civo_resource"
+	KeyKind       = "civo_kind"
+	KeyOperation  = "civo_operation"
+	KeyID         = "civo_id"
+	KeyDurationMs = "civo_duration_ms"
+	KeyRetries    = "civo_retries"
+	KeyOutcome    = "civo_outcome"
+)
+
+// Operation names
+const (
+	OperationCreate = "create"
+	OperationRead   = "read"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+)
+
+// Kinds of schema.Resource
+const (
+	KindResource   = "resource"
+	KindDataSource = "data_source"
+)
+
+// Outcomes of an operation
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+type retryCounterKey struct{}
+
+// RecordRetry increments the retry counter of the operation running in ctx.
+// It is a no-op if ctx doesn't belong to an instrumented operation.
+func RecordRetry(ctx context.Context) {
+	if counter, ok := ctx.Value(retryCounterKey{}).(*int64); ok {
+		atomic.AddInt64(counter, 1)
+	}
+}
+
+// Instrument wraps the CRUD functions of the given resource so that every call
+// logs its duration, retries and outcome
+func Instrument(name, kind string, r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(name, kind, OperationCreate, r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrap(name, kind, OperationRead, r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(name, kind, OperationUpdate, r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = wrap(name, kind, OperationDelete, r.DeleteContext)
+	}
+}
+
+// contextFunc matches schema.CreateContextFunc, ReadContextFunc, UpdateContextFunc and DeleteContextFunc
+type contextFunc interface {
+	~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+}
+
+func wrap[F contextFunc](name, kind, operation string, fn F) F {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		var retries int64
+		ctx = context.WithValue(ctx, retryCounterKey{}, &retries)
+		ctx = tflog.SetField(ctx, KeyResource, name)
+		ctx = tflog.SetField(ctx, KeyKind, kind)
+		ctx = tflog.SetField(ctx, KeyOperation, operation)
+
+		start := time.Now()
+		diags := fn(ctx, d, m)
+
+		outcome := OutcomeSuccess
+		if diags.HasError() {
+			outcome = OutcomeError
+		}
+
+		tflog.Info(ctx, "civo operation finished", map[string]interface{}{
+			KeyID:         d.Id(),
+			KeyDurationMs: time.Since(start).Milliseconds(),
+			KeyRetries:    atomic.LoadInt64(&retries),
+			KeyOutcome:    outcome,
+		})
+
+		return diags
+	}
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestInstrument(t *testing.T) {
+	var retries *int64
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			RecordRetry(ctx)
+			RecordRetry(ctx)
+			retries, _ = ctx.Value(retryCounterKey{}).(*int64)
+			return diag.Errorf("boom")
+		},
+	}
+
+	Instrument("civo_test", KindResource, r)
+
+	if r.ReadContext != nil || r.UpdateContext != nil || r.DeleteContext != nil {
+		t.Fatalf("expected nil operations to stay nil")
+	}
+
+	diags := r.CreateContext(context.Background(), r.TestResourceData(), nil)
+	if !diags.HasError() || diags[0].Summary != "boom" {
+		t.Fatalf("expected the wrapped diagnostics to be returned, got %v", diags)
+	}
+
+	if retries == nil {
+		t.Fatalf("expected a retry counter in the operation context")
+	}
+	if got := atomic.LoadInt64(retries); got != 2 {
+		t.Fatalf("expected 2 retries, got %d", got)
+	}
+}
+
+func TestRecordRetryWithoutOperation(t *testing.T) {
+	// must not panic when the context doesn't belong to an instrumented operation
+	RecordRetry(context.Background())
+}
@@ -0,0 +1,221 @@
+// Package redact keeps secrets out of the provider diagnostics and logs.
+// Secrets known at runtime (the API token, generated passwords, access keys...) are
+// registered with Register, and everything that matches them or a well known secret
+// pattern (bearer headers, kubeconfig credentials) is replaced by Placeholder.
+package redact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Placeholder is the text that replaces a secret
+const Placeholder = "[REDACTED]"
+
+// minSecretLength avoids redacting short values, like an empty or one char password,
+// that would mangle unrelated text
+const minSecretLength = 6
+
+var (
+	mu      sync.RWMutex
+	secrets = map[string]struct{}{}
+
+	// patterns are secrets we can spot without knowing their value, the first group is kept
+	patterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`),
+		regexp.MustCompile(`(?i)((?:token|client-key-data|client-certificate-data|password)\s*:\s*"?)[^\s"',]+`),
+		regexp.MustCompile(`(?i)("(?:token|api_key|apikey|password|secret_access_key)"\s*:\s*")[^"]+`),
+	}
+
+	wrapLogOnce sync.Once
+)
+
+// Register adds values that must never be shown in diagnostics or logs
+func Register(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, value := range values {
+		if len(value) >= minSecretLength {
+			secrets[value] = struct{}{}
+		}
+	}
+}
+
+// String returns s with every registered secret and secret pattern replaced by Placeholder
+func String(s string) string {
+	if s == "" {
+		return s
+	}
+
+	mu.RLock()
+	known := make([]string, 0, len(secrets))
+	for secret := range secrets {
+		known = append(known, secret)
+	}
+	mu.RUnlock()
+
+	// longest first, so a secret containing another one is fully replaced
+	sort.Slice(known, func(i, j int) bool { return len(known[i]) > len(known[j]) })
+	for _, secret := range known {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+
+	for _, pattern := range patterns {
+		s = pattern.ReplaceAllString(s, "${1}"+Placeholder)
+	}
+
+	return s
+}
+
+// Diagnostics returns a copy of diags with the summary and detail of each one redacted
+func Diagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	if len(diags) == 0 {
+		return diags
+	}
+
+	redacted := make(diag.Diagnostics, len(diags))
+	for i, d := range diags {
+		d.Summary = String(d.Summary)
+		d.Detail = String(d.Detail)
+		redacted[i] = d
+	}
+
+	return redacted
+}
+
+type writer struct {
+	w io.Writer
+}
+
+func (r *writer) Write(p []byte) (int, error) {
+	if _, err := r.w.Write([]byte(String(string(p)))); err != nil {
+		return 0, err
+	}
+	// report the original length, the redacted text is usually shorter or longer
+	return len(p), nil
+}
+
+// NewWriter returns a writer that redacts everything before passing it to w
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+// WrapLogOutput makes the standard logger, used by every log.Printf of the provider,
+// redact its output. It is safe to call it more than once.
+func WrapLogOutput() {
+	wrapLogOnce.Do(func() {
+		log.SetOutput(NewWriter(log.Writer()))
+	})
+}
+
+// Instrument wraps the CRUD functions, the CustomizeDiff and the importer of the given
+// resource so that their diagnostics and errors are redacted, and a panic is turned into a
+// redacted error diagnostic. Validation functions aren't wrapped, they only see the
+// configuration, never values coming from the API
+func Instrument(r *schema.Resource) {
+	if r.CreateContext != nil {
+		r.CreateContext = wrap(r.CreateContext)
+	}
+	if r.ReadContext != nil {
+		r.ReadContext = wrap(r.ReadContext)
+	}
+	if r.UpdateContext != nil {
+		r.UpdateContext = wrap(r.UpdateContext)
+	}
+	if r.DeleteContext != nil {
+		r.DeleteContext = wrap(r.DeleteContext)
+	}
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = wrapCustomizeDiff(r.CustomizeDiff)
+	}
+	if r.Importer != nil {
+		if r.Importer.StateContext != nil {
+			r.Importer.StateContext = wrapImport(r.Importer.StateContext)
+		}
+		if r.Importer.State != nil {
+			importState := r.Importer.State
+			r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				data, err := importState(d, m)
+				return data, Error(err)
+			}
+		}
+	}
+}
+
+// Error returns err with its message redacted, or nil
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.New(String(err.Error()))
+}
+
+// contextFunc matches schema.CreateContextFunc, ReadContextFunc, UpdateContextFunc and DeleteContextFunc
+type contextFunc interface {
+	~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+}
+
+func wrap[F contextFunc](fn F) F {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+		defer func() {
+			if r := recover(); r != nil {
+				diags = append(diags, panicDiagnostic(r))
+			}
+			diags = Diagnostics(diags)
+		}()
+
+		return fn(ctx, d, m)
+	}
+}
+
+func wrapCustomizeDiff(fn schema.CustomizeDiffFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicDiag := panicDiagnostic(r)
+				err = fmt.Errorf("%s: %s", panicDiag.Summary, panicDiag.Detail)
+			}
+			err = Error(err)
+		}()
+
+		return fn(ctx, d, m)
+	}
+}
+
+func wrapImport(fn schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) (data []*schema.ResourceData, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicDiag := panicDiagnostic(r)
+				err = fmt.Errorf("%s: %s", panicDiag.Summary, panicDiag.Detail)
+			}
+			err = Error(err)
+		}()
+
+		return fn(ctx, d, m)
+	}
+}
+
+// panicDiagnostic logs the recovered panic with its stack, redacted, and returns the error
+// diagnostic replacing it
+func panicDiagnostic(r interface{}) diag.Diagnostic {
+	log.Printf("[ERROR] panic in the Civo provider: %s", String(fmt.Sprintf("%v\n%s", r, debug.Stack())))
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "[ERR] unexpected error in the Civo provider",
+		Detail:   String(fmt.Sprintf("%v", r)),
+	}
+}
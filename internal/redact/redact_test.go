@@ -0,0 +1,113 @@
+package redact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestString(t *testing.T) {
+	Register("s3cr3t-api-token", "abc")
+
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"registered secret", "token s3cr3t-api-token is invalid", "token [REDACTED] is invalid"},
+		{"short values are not registered", "abc def", "abc def"},
+		{"bearer header", "Authorization: bearer eyJhbGciOi.payload", "Authorization: bearer [REDACTED]"},
+		{"kubeconfig token", "    token: kube-token-value\n", "    token: [REDACTED]\n"},
+		{"kubeconfig client key", "client-key-data: LS0tLS1CRUdJTi", "client-key-data: [REDACTED]"},
+		{"json password", `{"password":"hunter22"}`, `{"password":"[REDACTED]"}`},
+		{"nothing to redact", "network not found", "network not found"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := String(c.input); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	Register("writer-secret-value")
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	input := []byte("[DEBUG] using writer-secret-value\n")
+	n, err := w.Write(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != len(input) {
+		t.Errorf("expected %d bytes written, got %d", len(input), n)
+	}
+	if strings.Contains(buf.String(), "writer-secret-value") {
+		t.Errorf("secret leaked to the log: %q", buf.String())
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	Register("instrument-secret")
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		ReadContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			return diag.FromErr(errors.New("failed with instrument-secret"))
+		},
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			panic("boom: instrument-secret")
+		},
+	}
+	Instrument(r)
+
+	d := r.TestResourceData()
+
+	diags := r.ReadContext(context.Background(), d, nil)
+	if len(diags) != 1 || strings.Contains(diags[0].Summary, "instrument-secret") {
+		t.Errorf("expected a redacted diagnostic, got %+v", diags)
+	}
+
+	diags = r.DeleteContext(context.Background(), d, nil)
+	if !diags.HasError() {
+		t.Fatalf("expected the panic to become an error diagnostic")
+	}
+	if strings.Contains(diags[0].Detail, "instrument-secret") {
+		t.Errorf("secret leaked in the panic diagnostic: %q", diags[0].Detail)
+	}
+}
+
+func TestInstrumentCustomizeDiffAndImport(t *testing.T) {
+	Register("customize-secret")
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, _ interface{}) error {
+			return errors.New("plan failed with customize-secret")
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				panic("boom: customize-secret")
+			},
+		},
+	}
+	Instrument(r)
+
+	if err := r.CustomizeDiff(context.Background(), nil, nil); err == nil || strings.Contains(err.Error(), "customize-secret") {
+		t.Errorf("expected a redacted error, got %v", err)
+	}
+
+	_, err := r.Importer.StateContext(context.Background(), r.TestResourceData(), nil)
+	if err == nil || strings.Contains(err.Error(), "customize-secret") {
+		t.Errorf("expected the panic to become a redacted error, got %v", err)
+	}
+}
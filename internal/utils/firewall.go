@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/civo/civogo"
+)
+
+// ValidateFirewallRulePorts checks the ports of a firewall rule against its protocol:
+// tcp and udp rules need a port range, icmp has no ports so a port range can't be set
+func ValidateFirewallRulePorts(protocol, portRange string) error {
+	switch protocol {
+	case "icmp":
+		if portRange != "" {
+			return fmt.Errorf("port_range (%s) can't be set when protocol is icmp, icmp has no ports", portRange)
+		}
+	default:
+		if portRange == "" {
+			return fmt.Errorf("port_range is required if protocol is tcp or udp")
+		}
+	}
+
+	return nil
+}
+
+// ValidatePortRange checks the value is a port, e.g. 80, or a range of ports, e.g. 80-443,
+// with ports between 1 and 65535 and the start of a range not after its end
+func ValidatePortRange(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be string", k)}
+	}
+
+	start, end, isRange := strings.Cut(strings.ReplaceAll(value, " ", ""), "-")
+	if !isRange {
+		end = start
+	}
+
+	first, err := parsePort(start)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a port or a range of ports like 80-443, %s. Got %q", k, err, value)}
+	}
+	last, err := parsePort(end)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a port or a range of ports like 80-443, %s. Got %q", k, err, value)}
+	}
+
+	if first > last {
+		return nil, []error{fmt.Errorf("%s must be a range of ports from the lowest to the highest, e.g. %d-%d. Got %q", k, last, first, value)}
+	}
+
+	return nil, nil
+}
+
+// parsePort parses a port number between 1 and 65535
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q isn't a number", s)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%d isn't between 1 and 65535", port)
+	}
+	return port, nil
+}
+
+// ValidateFirewallCIDR checks the value is a CIDR, e.g. 192.168.1.0/24, or a single IP address
+func ValidateFirewallCIDR(v interface{}, k string) (ws []string, es []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be string", k)}
+	}
+
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return nil, nil
+	}
+	if net.ParseIP(value) != nil {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("%s must be a CIDR like 192.168.1.0/24 or 0.0.0.0/0, or an IP address. Got %q", k, value)}
+}
+
+// DefaultFirewallName returns the name civo_network gives the default firewall of a network
+func DefaultFirewallName(networkLabel string) string {
+	return fmt.Sprintf("%s-default", networkLabel)
+}
+
+// FindDefaultFirewall returns the default firewall of the network, the one named after it like
+// civo_network creates it. It returns nil if the network has none
+func FindDefaultFirewall(apiClient *civogo.Client, network *civogo.Network) (*civogo.Firewall, error) {
+	firewalls, err := apiClient.ListFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewalls: %s", err)
+	}
+
+	for _, firewall := range firewalls {
+		if firewall.NetworkID == network.ID && strings.EqualFold(firewall.Name, DefaultFirewallName(network.Label)) {
+			return &firewall, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ReplacingChanges returns the keys, among the given ones, changed on an existing resource.
+// They are the keys forcing its replacement, so nothing is returned when it's being created
+func ReplacingChanges(d *schema.ResourceDiff, keys ...string) []string {
+	if d.Id() == "" {
+		return nil
+	}
+
+	changed := []string{}
+	for _, key := range keys {
+		if d.HasChange(key) {
+			changed = append(changed, key)
+		}
+	}
+
+	return changed
+}
+
+// ReplacementSummary describes the replacement of a resource, what forces it and what it affects
+func ReplacementSummary(resource string, changed, impact []string) string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "%s will be destroyed and created again, because of the change of %s.", resource, strings.Join(changed, ", "))
+	for _, line := range impact {
+		fmt.Fprintf(&summary, "\n  - %s", line)
+	}
+
+	return summary.String()
+}
+
+// LogReplacement logs a warning summarizing the replacement of a resource. The plan already shows it
+// with the -/+ marker, this makes it stand out in the logs (TF_LOG=WARN), as the SDK can't attach
+// warnings to a plan
+func LogReplacement(resource string, changed, impact []string) {
+	if len(changed) == 0 {
+		return
+	}
+
+	log.Printf("[WARN] %s", ReplacementSummary(resource, changed, impact))
+}
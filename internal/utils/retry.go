@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/civo/civogo"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// ReadRetryTimeout is how long reads keep retrying while the API answers with transient errors
+const ReadRetryTimeout = 5 * time.Minute
+
+// civogo puts the HTTP status code in the message of the errors it can't map to a known type
+var serverErrorCodeRegex = regexp.MustCompile(`code: 5\d\d\b`)
+
+var notFoundCodeRegex = regexp.MustCompile(`code: 404\b`)
+
+// IsTransientError reports whether err is a temporary API failure (5xx or a network problem)
+// that is worth retrying, rather than a definitive answer from the API
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, civogo.InternalServerError) || errors.Is(err, civogo.TimeoutError) {
+		return true
+	}
+
+	return serverErrorCodeRegex.MatchString(err.Error())
+}
+
+// IsNotFoundError reports whether err says the object doesn't exist, either as one of the
+// given civogo errors or as a 404 civogo couldn't map to a known type
+func IsNotFoundError(err error, notFound ...error) bool {
+	if err == nil {
+		return false
+	}
+
+	for _, target := range notFound {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return notFoundCodeRegex.MatchString(err.Error())
+}
+
+// RetryOnTransientError calls f until it succeeds, fails with a non-transient error or the timeout expires.
+// The returned error is the last one returned by f, so it can be checked with errors.Is
+func RetryOnTransientError(ctx context.Context, timeout time.Duration, f func() error) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		if IsTransientError(err) {
+			log.Printf("[WARN] transient error from the Civo API, retrying: %s", err)
+			return retry.RetryableError(err)
+		}
+
+		return retry.NonRetryableError(err)
+	})
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/civo/civogo"
+)
+
+func TestIsTransientError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"internal server error", civogo.InternalServerError, true},
+		{"timeout", civogo.TimeoutError, true},
+		{"bad gateway", errors.New("ResponseDecodeFailedError: failed to decode the response expected from the API - status: 502 Bad Gateway, code: 502, reason: <html>"), true},
+		{"service unavailable", errors.New("CommonError: Unknown error response - status: 503 Service Unavailable, code: 503, reason: {}"), true},
+		{"not found", civogo.DatabaseKubernetesClusterNotFoundError, false},
+		{"bad request", errors.New("CommonError: Unknown error response - status: 400 Bad Request, code: 400, reason: {}"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := IsTransientError(c.err); got != c.want {
+				t.Errorf("IsTransientError(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"known not found", civogo.DatabaseNetworkNotFoundError, true},
+		{"other not found", civogo.DatabaseKubernetesClusterNotFoundError, false},
+		{"unmapped 404", errors.New("CommonError: Unknown error response - status: 404 Not Found, code: 404, reason: {}"), true},
+		{"internal server error", civogo.InternalServerError, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := IsNotFoundError(c.err, civogo.DatabaseNetworkNotFoundError); got != c.want {
+				t.Errorf("IsNotFoundError(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestRetryOnTransientError(t *testing.T) {
+	calls := 0
+	err := RetryOnTransientError(context.Background(), time.Minute, func() error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("status: 502 Bad Gateway, code: 502")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	err = RetryOnTransientError(context.Background(), time.Minute, func() error {
+		calls++
+		return civogo.DatabaseKubernetesClusterNotFoundError
+	})
+	if !errors.Is(err, civogo.DatabaseKubernetesClusterNotFoundError) {
+		t.Fatalf("expected the not found error to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected non transient errors not to be retried, got %d calls", calls)
+	}
+}
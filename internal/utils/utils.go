@@ -98,6 +98,64 @@ func ValidateNameSize(v interface{}, _ string) (ws []string, es []error) {
 	return warns, errs
 }
 
+// fqdnLabelRegex matches one label of a domain name, e.g. www in www.example.com
+var fqdnLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidateFQDN is a function to check a value is a fully qualified domain name, e.g. mail.example.com,
+// a trailing dot is allowed
+func ValidateFQDN(v interface{}, k string) (ws []string, es []error) {
+	var errs []error
+	var warns []string
+	value, ok := v.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected %s to be string", k))
+		return warns, errs
+	}
+
+	name := strings.TrimSuffix(value, ".")
+	labels := strings.Split(name, ".")
+	if len(name) > 253 || len(labels) < 2 {
+		errs = append(errs, fmt.Errorf("%s must be a fully qualified domain name, e.g. mail.example.com. Got %s", k, value))
+		return warns, errs
+	}
+
+	for _, label := range labels {
+		if !fqdnLabelRegex.MatchString(label) {
+			errs = append(errs, fmt.Errorf("%s must be a fully qualified domain name, %q isn't a valid label. Got %s", k, label, value))
+			return warns, errs
+		}
+	}
+
+	return warns, errs
+}
+
+// ValidateHostname is a function to check a value is a hostname following RFC 1123, either a single
+// label, e.g. web, or a fully qualified domain name, e.g. web.example.com
+func ValidateHostname(v interface{}, k string) (ws []string, es []error) {
+	var errs []error
+	var warns []string
+	value, ok := v.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected %s to be string", k))
+		return warns, errs
+	}
+
+	name := strings.TrimSuffix(value, ".")
+	if len(name) > 253 {
+		errs = append(errs, fmt.Errorf("%s can't be longer than 253 characters. Got %d", k, len(name)))
+		return warns, errs
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if !fqdnLabelRegex.MatchString(label) {
+			errs = append(errs, fmt.Errorf("%s must be a hostname made of letters, digits and hyphens, %q isn't a valid label. Got %s", k, label, value))
+			return warns, errs
+		}
+	}
+
+	return warns, errs
+}
+
 // ResourceCommonParseID is a function to parse the ID of a resource
 func ResourceCommonParseID(id string) (string, string, error) {
 	parts := strings.SplitN(id, ":", 2)
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFQDN(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"mail.example.com", true},
+		{"mail.example.com.", true},
+		{"a-b.example.co.uk", true},
+		{"example", false},
+		{"mail example.com", false},
+		{"-mail.example.com", false},
+		{"mail..example.com", false},
+		{"mail_1.example.com", false},
+	}
+
+	for _, c := range cases {
+		_, errs := ValidateFQDN(c.value, "reverse_dns")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("ValidateFQDN(%q) returned %v, want valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"web", true},
+		{"web-1.example.com", true},
+		{"web.example.com.", true},
+		{"", false},
+		{"web 1", false},
+		{"web_1", false},
+		{"-web", false},
+		{"web..example.com", false},
+		{strings.Repeat("a", 64), false},
+	}
+
+	for _, c := range cases {
+		_, errs := ValidateHostname(c.value, "hostname")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("ValidateHostname(%q) returned %v, want valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestValidateFirewallRulePorts(t *testing.T) {
+	cases := []struct {
+		protocol  string
+		portRange string
+		valid     bool
+	}{
+		{"tcp", "80", true},
+		{"udp", "53", true},
+		{"tcp", "", false},
+		{"icmp", "", true},
+		{"icmp", "80", false},
+	}
+
+	for _, c := range cases {
+		err := ValidateFirewallRulePorts(c.protocol, c.portRange)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateFirewallRulePorts(%q, %q) returned %v, want valid = %t", c.protocol, c.portRange, err, c.valid)
+		}
+	}
+}
+
+func TestValidatePortRange(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"80", true},
+		{"1-65535", true},
+		{"80-80", true},
+		{" 80 - 443 ", true},
+		{"0", false},
+		{"65536", false},
+		{"443-80", false},
+		{"80-", false},
+		{"http", false},
+		{"80,443", false},
+	}
+
+	for _, c := range cases {
+		_, errs := ValidatePortRange(c.value, "port_range")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("ValidatePortRange(%q) returned %v, want valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestValidateFirewallCIDR(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"0.0.0.0/0", true},
+		{"192.168.1.0/24", true},
+		{"1.2.3.4", true},
+		{"2001:db8::/32", true},
+		{"192.168.1.0/33", false},
+		{"192.168.1", false},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		_, errs := ValidateFirewallCIDR(c.value, "cidr")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("ValidateFirewallCIDR(%q) returned %v, want valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestReplacementSummary(t *testing.T) {
+	got := ReplacementSummary("the network prod", []string{"region"}, []string{"2 instances are in the network", "its firewalls are deleted"})
+	want := "the network prod will be destroyed and created again, because of the change of region.\n" +
+		"  - 2 instances are in the network\n" +
+		"  - its firewalls are deleted"
+
+	if got != want {
+		t.Errorf("ReplacementSummary returned %q, want %q", got, want)
+	}
+}